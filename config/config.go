@@ -0,0 +1,298 @@
+// Package config fills struct fields from environment variables or a map of
+// strings, using the parsers of the unit types in this module.
+//
+// Fields are selected with a "real" struct tag naming the variable, followed
+// by optional comma-separated options:
+//
+//	type Server struct {
+//		MaxBody data.Size  `real:"MAX_BODY,default=10MiB,min=1KiB,max=1GiB"`
+//		Limit   data.Speed `real:"LIMIT,default=10Mbps"`
+//		Alarm   temperature.Temperature `real:"ALARM,required"`
+//	}
+//
+// Supported options are default=VALUE, min=VALUE, max=VALUE and required.
+// Values may not contain commas. Bounds are parsed like the field itself and
+// compared with the ordering of the underlying type, so "min=1KiB" on a
+// data.Size is 1024 bytes.
+//
+// Any type implementing encoding.TextUnmarshaler is supported, including
+// data.Size, data.Speed and temperature.Temperature, as well as strings,
+// booleans, integers, floats and time.Duration. Untagged struct fields are
+// loaded recursively.
+package config
+
+import (
+	"cmp"
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TagName is the struct tag read by the loaders.
+const TagName = "real"
+
+// ErrRequired is returned, wrapped in a FieldError, when a required value is
+// missing.
+var ErrRequired = errors.New("required value is missing")
+
+// FieldError reports a struct field that could not be loaded.
+type FieldError struct {
+	// Field is the path of the struct field, such as "Server.MaxBody".
+	Field string
+	// Key is the variable name from the struct tag.
+	Key string
+	// Input is the offending input.
+	Input string
+	// Err is the underlying parse or validation error.
+	Err error
+}
+
+// Error implements error.
+func (e *FieldError) Error() string {
+	if errors.Is(e.Err, ErrRequired) {
+		return fmt.Sprintf("config: %s (%s): %v", e.Field, e.Key, e.Err)
+	}
+	return fmt.Sprintf("config: %s (%s): invalid value %q: %v",
+		e.Field, e.Key, e.Input, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Load fills the tagged fields of the struct pointed to by dst from the
+// environment.
+func Load(dst any) error {
+	return LoadFunc(dst, os.LookupEnv)
+}
+
+// LoadMap fills the tagged fields of the struct pointed to by dst from values.
+func LoadMap(dst any, values map[string]string) error {
+	return LoadFunc(dst, func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	})
+}
+
+// LoadFunc fills the tagged fields of the struct pointed to by dst with the
+// values returned by lookup. Fields whose variable is not found keep their
+// default from the tag, or their current value when there is none.
+//
+// Every field is processed; the returned error joins a *FieldError for each
+// field that failed.
+func LoadFunc(dst any, lookup func(key string) (string, bool)) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() ||
+		v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: expected a pointer to a struct, got %T", dst)
+	}
+
+	var errs []error
+	loadStruct(v.Elem(), v.Elem().Type().Name(), lookup, &errs)
+	return errors.Join(errs...)
+}
+
+func loadStruct(
+	v reflect.Value, path string, lookup func(string) (string, bool),
+	errs *[]error,
+) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		tag, ok := field.Tag.Lookup(TagName)
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.IsExported() &&
+				!isTextUnmarshaler(v.Field(i)) {
+				loadStruct(v.Field(i), fieldPath, lookup, errs)
+			}
+			continue
+		}
+		if tag == "-" {
+			continue
+		}
+
+		err := loadField(v.Field(i), field, fieldPath, tag, lookup)
+		if err != nil {
+			*errs = append(*errs, err)
+		}
+	}
+}
+
+type options struct {
+	key      string
+	def      *string
+	min, max *string
+	required bool
+}
+
+func parseTag(tag string) (options, error) {
+	parts := strings.Split(tag, ",")
+	opts := options{key: strings.TrimSpace(parts[0])}
+	if opts.key == "" {
+		return opts, errors.New("missing variable name in tag")
+	}
+
+	for _, part := range parts[1:] {
+		name, value, hasValue := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "required" && !hasValue:
+			opts.required = true
+		case name == "default" && hasValue:
+			opts.def = &value
+		case name == "min" && hasValue:
+			opts.min = &value
+		case name == "max" && hasValue:
+			opts.max = &value
+		default:
+			return opts, fmt.Errorf("invalid tag option %q", part)
+		}
+	}
+	return opts, nil
+}
+
+func loadField(
+	v reflect.Value, field reflect.StructField, path, tag string,
+	lookup func(string) (string, bool),
+) error {
+	opts, err := parseTag(tag)
+	if err != nil {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	if !field.IsExported() {
+		return fmt.Errorf("config: %s: field is not exported", path)
+	}
+
+	fail := func(input string, err error) error {
+		return &FieldError{Field: path, Key: opts.key, Input: input, Err: err}
+	}
+
+	input, ok := lookup(opts.key)
+	if !ok {
+		switch {
+		case opts.required:
+			return fail("", ErrRequired)
+		case opts.def == nil:
+			return nil
+		}
+		input = *opts.def
+	}
+
+	value := reflect.New(v.Type()).Elem()
+	if err := set(value, input); err != nil {
+		return fail(input, err)
+	}
+
+	if opts.min != nil {
+		limit, c, err := compareTo(value, *opts.min)
+		if err != nil {
+			return fmt.Errorf("config: %s: invalid minimum %q: %w",
+				path, *opts.min, err)
+		}
+		if c < 0 {
+			return fail(input, fmt.Errorf("below minimum %v", limit))
+		}
+	}
+	if opts.max != nil {
+		limit, c, err := compareTo(value, *opts.max)
+		if err != nil {
+			return fmt.Errorf("config: %s: invalid maximum %q: %w",
+				path, *opts.max, err)
+		}
+		if c > 0 {
+			return fail(input, fmt.Errorf("above maximum %v", limit))
+		}
+	}
+
+	v.Set(value)
+	return nil
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+func isTextUnmarshaler(v reflect.Value) bool {
+	_, ok := reflect.New(v.Type()).Interface().(encoding.TextUnmarshaler)
+	return ok
+}
+
+// set parses input into v, which must be settable.
+func set(v reflect.Value, input string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(input))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(input)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(input)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(input)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(input, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		n, err := strconv.ParseUint(input, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(input, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// compareTo parses bound like v and compares v with it using the ordering of
+// their underlying numeric type.
+func compareTo(v reflect.Value, bound string) (any, int, error) {
+	limit := reflect.New(v.Type()).Elem()
+	if err := set(limit, bound); err != nil {
+		return nil, 0, err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return limit.Interface(), cmp.Compare(v.Int(), limit.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		return limit.Interface(), cmp.Compare(v.Uint(), limit.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return limit.Interface(), cmp.Compare(v.Float(), limit.Float()), nil
+	default:
+		return nil, 0, fmt.Errorf("min and max are not supported for %s",
+			v.Type())
+	}
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/real-go/data"
+	"github.com/Nadim147c/real-go/temperature"
+)
+
+type server struct {
+	MaxBody data.Size               `real:"MAX_BODY,default=10MiB,min=1KiB,max=1GiB"`
+	Limit   data.Speed              `real:"LIMIT,default=10Mbps"`
+	Alarm   temperature.Temperature `real:"ALARM,default=80C,max=100C"`
+	Timeout time.Duration           `real:"TIMEOUT,default=30s"`
+	Workers int                     `real:"WORKERS,min=1"`
+	Name    string                  `real:"NAME"`
+	Debug   bool                    `real:"DEBUG"`
+	Ignored string                  `real:"-"`
+	Cache   cache
+}
+
+type cache struct {
+	Size data.Size `real:"CACHE_SIZE,required"`
+}
+
+func TestLoadMap(t *testing.T) {
+	var got server
+	got.Workers = 4
+	got.Ignored = "kept"
+
+	err := LoadMap(&got, map[string]string{
+		"MAX_BODY":   "1.5 MiB",
+		"ALARM":      "68F",
+		"NAME":       "edge",
+		"DEBUG":      "true",
+		"CACHE_SIZE": "512MiB",
+		"-":          "ignored",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.MaxBody != data.MiB+512*data.KiB {
+		t.Errorf("MaxBody = %v", got.MaxBody)
+	}
+	if got.Limit != data.Speed(10*data.Mb) {
+		t.Errorf("Limit = %v", got.Limit)
+	}
+	if got.Alarm != temperature.Fahrenheit(68) {
+		t.Errorf("Alarm = %v", got.Alarm)
+	}
+	if got.Timeout != 30*time.Second {
+		t.Errorf("Timeout = %v", got.Timeout)
+	}
+	if got.Workers != 4 {
+		t.Errorf("Workers = %d, want unchanged 4", got.Workers)
+	}
+	if got.Name != "edge" || !got.Debug || got.Ignored != "kept" {
+		t.Errorf("got %+v", got)
+	}
+	if got.Cache.Size != 512*data.MiB {
+		t.Errorf("Cache.Size = %v", got.Cache.Size)
+	}
+}
+
+func TestLoadMapErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		field  string
+		input  string
+		want   string
+	}{
+		{
+			name:   "bad size",
+			values: map[string]string{"MAX_BODY": "10XB", "CACHE_SIZE": "1"},
+			field:  "server.MaxBody",
+			input:  "10XB",
+			want:   `config: server.MaxBody (MAX_BODY): invalid value "10XB"`,
+		},
+		{
+			name:   "below minimum",
+			values: map[string]string{"MAX_BODY": "10B", "CACHE_SIZE": "1"},
+			field:  "server.MaxBody",
+			input:  "10B",
+			want:   "below minimum 1.00 kiB",
+		},
+		{
+			name:   "above maximum",
+			values: map[string]string{"MAX_BODY": "2GiB", "CACHE_SIZE": "1"},
+			field:  "server.MaxBody",
+			input:  "2GiB",
+			want:   "above maximum 1.00 GiB",
+		},
+		{
+			name:   "temperature above maximum",
+			values: map[string]string{"ALARM": "250F", "CACHE_SIZE": "1"},
+			field:  "server.Alarm",
+			input:  "250F",
+			want:   "above maximum 100.00 °C",
+		},
+		{
+			name:   "int below minimum",
+			values: map[string]string{"WORKERS": "0", "CACHE_SIZE": "1"},
+			field:  "server.Workers",
+			input:  "0",
+			want:   "below minimum 1",
+		},
+		{
+			name:   "required",
+			values: map[string]string{},
+			field:  "server.Cache.Size",
+			want:   "config: server.Cache.Size (CACHE_SIZE): required value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got server
+			err := LoadMap(&got, tt.values)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error %q does not contain %q", err, tt.want)
+			}
+
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("expected a FieldError, got %T", err)
+			}
+			if fe.Field != tt.field || fe.Input != tt.input {
+				t.Fatalf("got field %q input %q, want %q %q",
+					fe.Field, fe.Input, tt.field, tt.input)
+			}
+		})
+	}
+}
+
+func TestLoadMapReportsEveryField(t *testing.T) {
+	var got server
+	err := LoadMap(&got, map[string]string{"MAX_BODY": "x", "LIMIT": "y"})
+
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fe *FieldError
+		if errors.As(e, &fe) {
+			fields = append(fields, fe.Field)
+		}
+	}
+
+	want := "server.MaxBody server.Limit server.Cache.Size"
+	if strings.Join(fields, " ") != want {
+		t.Fatalf("got fields %v, want %s", fields, want)
+	}
+	if !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected ErrRequired in %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("REAL_TEST_SIZE", "2 GiB 512 MiB")
+
+	var got struct {
+		Size data.Size `real:"REAL_TEST_SIZE"`
+	}
+	if err := Load(&got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Size != 2*data.GiB+512*data.MiB {
+		t.Fatalf("got %v", got.Size)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  any
+	}{
+		{"nil", nil},
+		{"not a pointer", server{}},
+		{"pointer to non-struct", new(int)},
+		{"bad tag option", &struct {
+			A int `real:"A,bogus"`
+		}{}},
+		{"empty key", &struct {
+			A int `real:",default=1"`
+		}{}},
+		{"unsupported type", &struct {
+			A []int `real:"A,default=1"`
+		}{}},
+		{"unsupported bound", &struct {
+			A string `real:"A,default=x,min=a"`
+		}{}},
+		{"invalid bound", &struct {
+			A data.Size `real:"A,default=1,min=lots"`
+		}{}},
+		{"unexported", &struct {
+			a int `real:"A"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := LoadMap(tt.dst, nil); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
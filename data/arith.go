@@ -0,0 +1,219 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrOverflow is matched by errors.Is for every *OverflowError.
+	ErrOverflow = errors.New("arithmetic overflow")
+	// ErrDivisionByZero is returned when dividing a Size or Speed by zero.
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+// OverflowError reports an arithmetic operation whose result does not fit in
+// the Size or Speed it was computed for. It matches ErrOverflow with errors.Is.
+type OverflowError struct {
+	// Op is the operator, one of "+", "-", "*" and "/".
+	Op string
+	// X and Y are the operands, as Size, Speed, int64 or uint64.
+	X, Y any
+}
+
+// Error implements error.
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("%v: %v %s %v", ErrOverflow, raw(e.X), e.Op, raw(e.Y))
+}
+
+// raw returns the underlying integer of a Size or Speed operand.
+func raw(v any) any {
+	switch v := v.(type) {
+	case Size:
+		return int64(v)
+	case Speed:
+		return uint64(v)
+	default:
+		return v
+	}
+}
+
+// Is reports whether target is ErrOverflow.
+func (e *OverflowError) Is(target error) bool {
+	return target == ErrOverflow
+}
+
+// AddChecked returns d + o, or an *OverflowError if the sum does not fit in a
+// Size.
+func (d Size) AddChecked(o Size) (Size, error) {
+	r := d + o
+	if (o > 0 && r < d) || (o < 0 && r > d) {
+		return 0, &OverflowError{Op: "+", X: d, Y: o}
+	}
+	return r, nil
+}
+
+// SubChecked returns d - o, or an *OverflowError if the difference does not fit
+// in a Size.
+func (d Size) SubChecked(o Size) (Size, error) {
+	r := d - o
+	if (o > 0 && r > d) || (o < 0 && r < d) {
+		return 0, &OverflowError{Op: "-", X: d, Y: o}
+	}
+	return r, nil
+}
+
+// MulChecked returns d * n, or an *OverflowError if the product does not fit in
+// a Size.
+func (d Size) MulChecked(n int64) (Size, error) {
+	if d == 0 || n == 0 {
+		return 0, nil
+	}
+	r := int64(d) * n
+	if r/n != int64(d) || (n == -1 && d == math.MinInt64) {
+		return 0, &OverflowError{Op: "*", X: d, Y: n}
+	}
+	return Size(r), nil
+}
+
+// DivChecked returns d / n truncated toward zero. It returns ErrDivisionByZero
+// if n is zero and an *OverflowError for math.MinInt64 / -1.
+func (d Size) DivChecked(n int64) (Size, error) {
+	if n == 0 {
+		return 0, ErrDivisionByZero
+	}
+	if n == -1 && d == math.MinInt64 {
+		return 0, &OverflowError{Op: "/", X: d, Y: n}
+	}
+	return d / Size(n), nil
+}
+
+// AddSaturating returns d + o clamped to the range of a Size.
+func (d Size) AddSaturating(o Size) Size {
+	r, err := d.AddChecked(o)
+	if err != nil {
+		return saturate(o > 0)
+	}
+	return r
+}
+
+// SubSaturating returns d - o clamped to the range of a Size.
+func (d Size) SubSaturating(o Size) Size {
+	r, err := d.SubChecked(o)
+	if err != nil {
+		return saturate(o < 0)
+	}
+	return r
+}
+
+// MulSaturating returns d * n clamped to the range of a Size.
+func (d Size) MulSaturating(n int64) Size {
+	r, err := d.MulChecked(n)
+	if err != nil {
+		return saturate((d > 0) == (n > 0))
+	}
+	return r
+}
+
+// DivSaturating returns d / n clamped to the range of a Size. Dividing by zero
+// saturates in the direction of d, and 0 / 0 is zero.
+func (d Size) DivSaturating(n int64) Size {
+	if n == 0 {
+		if d == 0 {
+			return 0
+		}
+		return saturate(d > 0)
+	}
+	r, err := d.DivChecked(n)
+	if err != nil {
+		return saturate(true)
+	}
+	return r
+}
+
+// saturate returns the largest Size if positive is true and the smallest
+// otherwise.
+func saturate(positive bool) Size {
+	if positive {
+		return math.MaxInt64
+	}
+	return math.MinInt64
+}
+
+// AddChecked returns s + o, or an *OverflowError if the sum does not fit in a
+// Speed.
+func (s Speed) AddChecked(o Speed) (Speed, error) {
+	r, carry := bits.Add64(uint64(s), uint64(o), 0)
+	if carry != 0 {
+		return 0, &OverflowError{Op: "+", X: s, Y: o}
+	}
+	return Speed(r), nil
+}
+
+// SubChecked returns s - o, or an *OverflowError if o is greater than s, since
+// a Speed cannot be negative.
+func (s Speed) SubChecked(o Speed) (Speed, error) {
+	if o > s {
+		return 0, &OverflowError{Op: "-", X: s, Y: o}
+	}
+	return s - o, nil
+}
+
+// MulChecked returns s * n, or an *OverflowError if the product does not fit in
+// a Speed.
+func (s Speed) MulChecked(n uint64) (Speed, error) {
+	hi, lo := bits.Mul64(uint64(s), n)
+	if hi != 0 {
+		return 0, &OverflowError{Op: "*", X: s, Y: n}
+	}
+	return Speed(lo), nil
+}
+
+// DivChecked returns s / n. It returns ErrDivisionByZero if n is zero.
+func (s Speed) DivChecked(n uint64) (Speed, error) {
+	if n == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return s / Speed(n), nil
+}
+
+// AddSaturating returns s + o, or math.MaxUint64 if the sum overflows.
+func (s Speed) AddSaturating(o Speed) Speed {
+	r, err := s.AddChecked(o)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return r
+}
+
+// SubSaturating returns s - o, or zero if o is greater than s.
+func (s Speed) SubSaturating(o Speed) Speed {
+	r, err := s.SubChecked(o)
+	if err != nil {
+		return 0
+	}
+	return r
+}
+
+// MulSaturating returns s * n, or math.MaxUint64 if the product overflows.
+func (s Speed) MulSaturating(n uint64) Speed {
+	r, err := s.MulChecked(n)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return r
+}
+
+// DivSaturating returns s / n. Dividing a non-zero Speed by zero returns
+// math.MaxUint64, and 0 / 0 is zero.
+func (s Speed) DivSaturating(n uint64) Speed {
+	if n == 0 {
+		if s == 0 {
+			return 0
+		}
+		return math.MaxUint64
+	}
+	return s / Speed(n)
+}
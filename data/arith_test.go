@@ -0,0 +1,208 @@
+package data
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+const (
+	maxSize = Size(math.MaxInt64)
+	minSize = Size(math.MinInt64)
+)
+
+// sizeOp applies the checked operation named by op.
+func sizeOp(op string, x Size, y int64) (Size, error) {
+	switch op {
+	case "+":
+		return x.AddChecked(Size(y))
+	case "-":
+		return x.SubChecked(Size(y))
+	case "*":
+		return x.MulChecked(y)
+	default:
+		return x.DivChecked(y)
+	}
+}
+
+func TestSize_Checked(t *testing.T) {
+	tests := []struct {
+		name    string
+		x       Size
+		op      string
+		y       int64
+		want    Size
+		wantErr error
+	}{
+		{"add", GiB, "+", int64(MiB), GiB + MiB, nil},
+		{"add negative", KiB, "+", -2048, -KiB, nil},
+		{"add overflow", maxSize, "+", 1, 0, ErrOverflow},
+		{"add underflow", minSize, "+", -1, 0, ErrOverflow},
+		{"sub", GiB, "-", int64(MiB), GiB - MiB, nil},
+		{"sub overflow", maxSize, "-", -1, 0, ErrOverflow},
+		{"sub underflow", minSize, "-", 1, 0, ErrOverflow},
+		{"mul", EB, "*", 5, 5 * EB, nil},
+		{"mul negative", KiB, "*", -3, -3 * KiB, nil},
+		{"mul zero", maxSize, "*", 0, 0, nil},
+		{"mul overflow", 2 * EB, "*", 5, 0, ErrOverflow},
+		{"mul negative overflow", EB, "*", -10, 0, ErrOverflow},
+		{"mul min by -1", minSize, "*", -1, 0, ErrOverflow},
+		{"mul -1 by min", -1, "*", math.MinInt64, 0, ErrOverflow},
+		{"mul min by 1", minSize, "*", 1, minSize, nil},
+		{"div", GiB, "/", 1024, MiB, nil},
+		{"div truncates", -7, "/", 2, -3, nil},
+		{"div by zero", GiB, "/", 0, 0, ErrDivisionByZero},
+		{"div min by -1", minSize, "/", -1, 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sizeOp(tt.op, tt.x, tt.y)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSize_Saturating(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Size
+		want Size
+	}{
+		{"add", GiB.AddSaturating(MiB), GiB + MiB},
+		{"add overflow", maxSize.AddSaturating(1), maxSize},
+		{"add underflow", minSize.AddSaturating(-1), minSize},
+		{"sub", GiB.SubSaturating(MiB), GiB - MiB},
+		{"sub overflow", maxSize.SubSaturating(-1), maxSize},
+		{"sub underflow", minSize.SubSaturating(1), minSize},
+		{"mul overflow", (2 * EB).MulSaturating(5), maxSize},
+		{"mul negative overflow", (2 * EB).MulSaturating(-5), minSize},
+		{"mul both negative", (-2 * EB).MulSaturating(-5), maxSize},
+		{"div", GiB.DivSaturating(2), GiB / 2},
+		{"div min by -1", minSize.DivSaturating(-1), maxSize},
+		{"div by zero", GiB.DivSaturating(0), maxSize},
+		{"div negative by zero", (-GiB).DivSaturating(0), minSize},
+		{"div zero by zero", Zero.DivSaturating(0), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// speedOp applies the checked operation named by op.
+func speedOp(op string, x Speed, y uint64) (Speed, error) {
+	switch op {
+	case "+":
+		return x.AddChecked(Speed(y))
+	case "-":
+		return x.SubChecked(Speed(y))
+	case "*":
+		return x.MulChecked(y)
+	default:
+		return x.DivChecked(y)
+	}
+}
+
+func TestSpeed_Checked(t *testing.T) {
+	const maxSpeed = Speed(math.MaxUint64)
+
+	tests := []struct {
+		name    string
+		x       Speed
+		op      string
+		y       uint64
+		want    Speed
+		wantErr error
+	}{
+		{"add", 1, "+", 2, 3, nil},
+		{"add overflow", maxSpeed, "+", 1, 0, ErrOverflow},
+		{"sub", 3, "-", 2, 1, nil},
+		{"sub below zero", 1, "-", 2, 0, ErrOverflow},
+		{"mul", Speed(MB), "*", 3, Speed(3 * MB), nil},
+		{"mul overflow", maxSpeed, "*", 2, 0, ErrOverflow},
+		{"div", 9, "/", 2, 4, nil},
+		{"div by zero", 9, "/", 0, 0, ErrDivisionByZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := speedOp(tt.op, tt.x, tt.y)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeed_Saturating(t *testing.T) {
+	const maxSpeed = Speed(math.MaxUint64)
+
+	tests := []struct {
+		name string
+		got  Speed
+		want Speed
+	}{
+		{"add", Speed(1).AddSaturating(2), 3},
+		{"add overflow", maxSpeed.AddSaturating(1), maxSpeed},
+		{"sub below zero", Speed(1).SubSaturating(2), 0},
+		{"mul overflow", maxSpeed.MulSaturating(2), maxSpeed},
+		{"div by zero", Speed(1).DivSaturating(0), maxSpeed},
+		{"div zero by zero", Speed(0).DivSaturating(0), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverflowError(t *testing.T) {
+	_, err := maxSize.AddChecked(1)
+
+	var oe *OverflowError
+	if !errors.As(err, &oe) {
+		t.Fatalf("expected *OverflowError, got %T", err)
+	}
+	if oe.Op != "+" || oe.X != maxSize || oe.Y != Size(1) {
+		t.Fatalf("unexpected error fields: %+v", oe)
+	}
+
+	want := "arithmetic overflow: 9223372036854775807 + 1"
+	if err.Error() != want {
+		t.Fatalf("got %q, want %q", err, want)
+	}
+
+	_, err = Speed(math.MaxUint64).MulChecked(2)
+	want = "arithmetic overflow: 18446744073709551615 * 2"
+	if err.Error() != want {
+		t.Fatalf("got %q, want %q", err, want)
+	}
+}
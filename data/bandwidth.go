@@ -0,0 +1,222 @@
+package data
+
+import (
+	"cmp"
+	"context"
+	"io"
+	"math/bits"
+	"net"
+	"slices"
+	"sync"
+)
+
+// Bandwidth is a total Speed shared by many concurrent streams, such as all the
+// transfers of a daemon that must together stay under a site-wide cap.
+//
+// The total is divided by weighted max-min fair sharing: each open stream gets
+// a share in proportion to its weight, except that a stream is never given more
+// than its own cap, and what it cannot use is divided among the others. Shares
+// are recomputed whenever a stream opens, closes or changes, or the total
+// changes. A total of zero means unlimited, leaving only the caps.
+//
+// A Bandwidth is safe for concurrent use.
+type Bandwidth struct {
+	mu      sync.Mutex
+	total   Speed
+	opts    []Option
+	streams []*Stream
+}
+
+// NewBandwidth returns a Bandwidth with the given total. The options apply to
+// the Limiter of every stream; WithBurst sets the burst of each stream.
+func NewBandwidth(total Speed, opts ...Option) *Bandwidth {
+	return &Bandwidth{total: total, opts: opts}
+}
+
+// Limit returns the total.
+func (b *Bandwidth) Limit() Speed {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total
+}
+
+// SetLimit changes the total and reallocates the shares of all streams.
+func (b *Bandwidth) SetLimit(total Speed) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.total = total
+	b.allocateLocked()
+}
+
+// setCaps changes the total and the cap of every stream at once.
+func (b *Bandwidth) setCaps(total, limit Speed) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.total = total
+	for _, s := range b.streams {
+		s.limit = limit
+	}
+	b.allocateLocked()
+}
+
+// NewStream opens a stream that draws from b. A weight below 1 counts as 1. A
+// cap of zero leaves the stream uncapped. Close the stream when it is done so
+// that its share goes to the others.
+func (b *Bandwidth) NewStream(weight int, limit Speed) *Stream {
+	s := &Stream{
+		bandwidth: b,
+		weight:    max(weight, 1),
+		limit:     limit,
+		limiter:   NewLimiter(0, b.opts...),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.streams = append(b.streams, s)
+	b.allocateLocked()
+	return s
+}
+
+// allocateLocked recomputes the share of every stream by water-filling: the
+// streams whose cap is below their fair share are fixed at the cap, lowest cap
+// per weight first, and the rest split what remains by weight. The caller must
+// hold b.mu.
+func (b *Bandwidth) allocateLocked() {
+	if b.total == 0 {
+		for _, s := range b.streams {
+			s.setShare(s.limit)
+		}
+		return
+	}
+
+	// Sort by cap per weight, uncapped streams last.
+	order := slices.Clone(b.streams)
+	slices.SortFunc(order, func(x, y *Stream) int {
+		switch {
+		case x.limit == 0 && y.limit == 0:
+			return 0
+		case x.limit == 0:
+			return 1
+		case y.limit == 0:
+			return -1
+		}
+		// Compare x.limit/x.weight with y.limit/y.weight without dividing.
+		xhi, xlo := bits.Mul64(uint64(x.limit), uint64(y.weight))
+		yhi, ylo := bits.Mul64(uint64(y.limit), uint64(x.weight))
+		if xhi != yhi {
+			return cmp.Compare(xhi, yhi)
+		}
+		return cmp.Compare(xlo, ylo)
+	})
+
+	var weights uint64
+	for _, s := range order {
+		weights += uint64(s.weight)
+	}
+
+	remaining := b.total
+	for _, s := range order {
+		fair := fairShare(remaining, s.weight, weights)
+		if s.limit == 0 || s.limit > fair {
+			s.setShare(fair)
+		} else {
+			s.setShare(s.limit)
+		}
+		remaining -= min(s.share, remaining)
+		weights -= uint64(s.weight)
+	}
+}
+
+// fairShare returns total * weight / weights, which never overflows since
+// weight <= weights. The result is at least 1 byte per second, because a
+// Limiter treats zero as unlimited.
+func fairShare(total Speed, weight int, weights uint64) Speed {
+	hi, lo := bits.Mul64(uint64(total), uint64(weight))
+	share, _ := bits.Div64(hi, lo, weights)
+	return max(Speed(share), 1)
+}
+
+// Stream is one consumer of a Bandwidth. Its reader, writer and connection
+// wrappers all draw from the same share.
+type Stream struct {
+	bandwidth *Bandwidth
+	limiter   *Limiter
+
+	// Guarded by bandwidth.mu.
+	weight int
+	limit  Speed
+	share  Speed
+	closed bool
+}
+
+func (s *Stream) setShare(share Speed) {
+	s.share = share
+	s.limiter.SetLimit(share)
+}
+
+// Speed returns the current share of the stream. Zero means unlimited.
+func (s *Stream) Speed() Speed {
+	s.bandwidth.mu.Lock()
+	defer s.bandwidth.mu.Unlock()
+	return s.share
+}
+
+// SetWeight changes the weight of the stream and reallocates the shares.
+func (s *Stream) SetWeight(weight int) {
+	s.bandwidth.mu.Lock()
+	defer s.bandwidth.mu.Unlock()
+	s.weight = max(weight, 1)
+	s.bandwidth.allocateLocked()
+}
+
+// SetCap changes the cap of the stream and reallocates the shares. A cap of
+// zero leaves the stream uncapped.
+func (s *Stream) SetCap(limit Speed) {
+	s.bandwidth.mu.Lock()
+	defer s.bandwidth.mu.Unlock()
+	s.limit = limit
+	s.bandwidth.allocateLocked()
+}
+
+// Close removes the stream from its Bandwidth and gives its share to the other
+// streams. The stream and its wrappers stop being limited. Close is
+// idempotent.
+func (s *Stream) Close() {
+	b := s.bandwidth
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	b.streams = slices.DeleteFunc(b.streams, func(o *Stream) bool {
+		return o == s
+	})
+	s.setShare(0)
+	b.allocateLocked()
+}
+
+// WaitN blocks until the stream may move n bytes, or until ctx is done. See
+// Limiter.WaitN.
+func (s *Stream) WaitN(ctx context.Context, n Size) error {
+	return s.limiter.WaitN(ctx, n)
+}
+
+// Reader returns a reader that reads from r within the share of the stream.
+// Only WithContext applies among the options.
+func (s *Stream) Reader(r io.Reader, opts ...Option) io.Reader {
+	return &LimitedReader{Limiter: s.limiter, r: r, ctx: newOptions(opts).ctx}
+}
+
+// Writer returns a writer that writes to w within the share of the stream.
+// Only WithContext applies among the options.
+func (s *Stream) Writer(w io.Writer, opts ...Option) io.Writer {
+	return &LimitedWriter{Limiter: s.limiter, w: w, ctx: newOptions(opts).ctx}
+}
+
+// Conn returns a connection whose reads and writes together stay within the
+// share of the stream. Deadlines also apply to the waits for the share. Only
+// WithContext applies among the options.
+func (s *Stream) Conn(c net.Conn, opts ...Option) net.Conn {
+	return newThrottledConn(newOptions(opts).ctx, c, s.limiter, s.limiter)
+}
@@ -0,0 +1,201 @@
+package data
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+type streamSpec struct {
+	weight int
+	limit  Speed
+}
+
+func shares(streams []*Stream) []Speed {
+	got := make([]Speed, len(streams))
+	for i, s := range streams {
+		got[i] = s.Speed()
+	}
+	return got
+}
+
+func TestBandwidth_Allocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   Speed
+		streams []streamSpec
+		want    []Speed
+	}{
+		{"single", 1000, []streamSpec{{1, 0}}, []Speed{1000}},
+		{"equal", 1000, []streamSpec{{1, 0}, {1, 0}}, []Speed{500, 500}},
+		{"weighted", 1000, []streamSpec{{1, 0}, {3, 0}}, []Speed{250, 750}},
+		{"zero weight", 1000, []streamSpec{{0, 0}, {-2, 0}}, []Speed{500, 500}},
+		{
+			"cap below share",
+			1000, []streamSpec{{1, 100}, {1, 0}, {1, 0}},
+			[]Speed{100, 450, 450},
+		},
+		{
+			"cap above share",
+			1000, []streamSpec{{1, 800}, {1, 0}},
+			[]Speed{500, 500},
+		},
+		{
+			"caps cascade",
+			1000, []streamSpec{{1, 600}, {1, 200}, {2, 0}},
+			[]Speed{266, 200, 534},
+		},
+		{
+			"cap per weight",
+			900, []streamSpec{{3, 400}, {1, 200}, {1, 0}},
+			[]Speed{400, 200, 300},
+		},
+		{
+			"all capped below total",
+			1000, []streamSpec{{1, 100}, {1, 200}},
+			[]Speed{100, 200},
+		},
+		{
+			"unlimited total",
+			0, []streamSpec{{1, 100}, {5, 0}},
+			[]Speed{100, 0},
+		},
+		{
+			"more streams than bytes",
+			1, []streamSpec{{1, 0}, {1, 0}},
+			[]Speed{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBandwidth(tt.total)
+			streams := make([]*Stream, len(tt.streams))
+			for i, spec := range tt.streams {
+				streams[i] = b.NewStream(spec.weight, spec.limit)
+			}
+			if got := shares(streams); !slices.Equal(got, tt.want) {
+				t.Errorf("shares = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBandwidth_Reconfigure(t *testing.T) {
+	b := NewBandwidth(Speed(MB))
+	x := b.NewStream(1, 0)
+	y := b.NewStream(1, 0)
+
+	steps := []struct {
+		name   string
+		change func()
+		x, y   Speed
+	}{
+		{"initial", func() {}, Speed(500 * KB), Speed(500 * KB)},
+		{"total", func() { b.SetLimit(Speed(GB)) }, Speed(GB / 2), Speed(GB / 2)},
+		{"weight", func() { x.SetWeight(3) }, Speed(750 * MB), Speed(250 * MB)},
+		{"cap", func() { x.SetCap(Speed(MB)) }, Speed(MB), Speed(999 * MB)},
+		{"close", func() { y.Close() }, Speed(MB), 0},
+		{"close again", func() { y.Close() }, Speed(MB), 0},
+		{"uncap", func() { x.SetCap(0) }, Speed(GB), 0},
+	}
+
+	for _, step := range steps {
+		step.change()
+		if gx, gy := x.Speed(), y.Speed(); gx != step.x || gy != step.y {
+			t.Fatalf("%s: shares = %v, %v, want %v, %v",
+				step.name, gx, gy, step.x, step.y)
+		}
+	}
+	if got := b.Limit(); got != Speed(GB) {
+		t.Fatalf("Limit() = %v, want %v", got, Speed(GB))
+	}
+}
+
+func TestStream_Reader(t *testing.T) {
+	clock := newFakeClock(true)
+	b := NewBandwidth(200, WithClock(clock))
+	s := b.NewStream(1, 0)
+	b.NewStream(1, 0)
+
+	data := bytes.Repeat([]byte("real"), 250)
+	got, err := io.ReadAll(s.Reader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("read %d bytes, want %d", len(got), len(data))
+	}
+	if elapsed := clock.Elapsed(); elapsed != 9*time.Second {
+		t.Fatalf("took %v, want 9s at half of the total", elapsed)
+	}
+}
+
+func TestStream_Conn(t *testing.T) {
+	clock := newFakeClock(true)
+	s := NewBandwidth(100, WithClock(clock)).NewStream(1, 0)
+	client, server := net.Pipe()
+	conn := s.Conn(client)
+	defer conn.Close()
+	defer server.Close()
+
+	data := bytes.Repeat([]byte("real"), 100)
+	go func() {
+		conn.Write(data)
+		conn.Close()
+	}()
+	got, err := io.ReadAll(server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("read %d bytes, want %d", len(got), len(data))
+	}
+	if elapsed := clock.Elapsed(); elapsed != 3*time.Second {
+		t.Fatalf("took %v, want 3s", elapsed)
+	}
+}
+
+func TestStream_Writer(t *testing.T) {
+	clock := newFakeClock(true)
+	s := NewBandwidth(100, WithClock(clock)).NewStream(1, 0)
+	s.Close()
+
+	var buf bytes.Buffer
+	if _, err := s.Writer(&buf).Write(make([]byte, 1000)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := clock.Elapsed(); elapsed != 0 {
+		t.Fatalf("closed stream was limited for %v", elapsed)
+	}
+}
+
+func TestStream_Unlimited(t *testing.T) {
+	b := NewBandwidth(0, WithClock(newFakeClock(true)))
+	s := b.NewStream(1, 0)
+	closed := b.NewStream(1, 100)
+	closed.Close()
+
+	data := bytes.Repeat([]byte("real"), 250)
+	for name, s := range map[string]*Stream{"uncapped": s, "closed": closed} {
+		src := &countingIO{r: bytes.NewReader(data)}
+		n, err := s.Reader(src).Read(make([]byte, len(data)))
+		if n != len(data) || err != nil {
+			t.Fatalf("%s: Read() = %d, %v, want %d, nil", name, n, err, len(data))
+		}
+		if src.reads != 1 {
+			t.Errorf("%s: made %d reads, want 1", name, src.reads)
+		}
+
+		dst := &countingIO{}
+		if _, err := s.Writer(dst).Write(data); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if dst.writes != 1 {
+			t.Errorf("%s: made %d writes, want 1", name, dst.writes)
+		}
+	}
+}
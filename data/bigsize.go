@@ -0,0 +1,251 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	islices "github.com/Nadim147c/real-go/internal/slices"
+)
+
+// BigSize is an arbitrary-precision quantity of data in bytes, for totals past
+// the roughly 9.2 EB a Size can hold.
+//
+// The zero value is zero bytes. A BigSize is immutable: its methods return new
+// values and never modify their receiver or arguments.
+type BigSize struct {
+	n *big.Int
+}
+
+// revive:disable exported
+
+// Units larger than a Size can represent.
+var (
+	ZB  = bigPow(1000, 7)
+	YB  = bigPow(1000, 8)
+	RB  = bigPow(1000, 9)
+	QB  = bigPow(1000, 10)
+	ZiB = bigPow(1024, 7)
+	YiB = bigPow(1024, 8)
+	RiB = bigPow(1024, 9)
+	QiB = bigPow(1024, 10)
+)
+
+// revive:enable exported
+
+func bigPow(base, exp int64) BigSize {
+	return BigSize{new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil)}
+}
+
+type bigUnit struct {
+	name  string
+	bytes *big.Rat
+}
+
+// bigUnitsBeyond lists, per family, the units after those of unitList.
+var bigUnitsBeyond = map[FormatUnit][]bigUnit{
+	FormatMetricByte: {
+		{"ZB", new(big.Rat).SetInt(ZB.n)}, {"YB", new(big.Rat).SetInt(YB.n)},
+		{"RB", new(big.Rat).SetInt(RB.n)}, {"QB", new(big.Rat).SetInt(QB.n)},
+	},
+	FormatBinaryByte: {
+		{"ZiB", new(big.Rat).SetInt(ZiB.n)}, {"YiB", new(big.Rat).SetInt(YiB.n)},
+		{"RiB", new(big.Rat).SetInt(RiB.n)}, {"QiB", new(big.Rat).SetInt(QiB.n)},
+	},
+	FormatMetricBit: {
+		{"Zb", new(big.Rat).SetFrac(ZB.n, big.NewInt(8))},
+		{"Yb", new(big.Rat).SetFrac(YB.n, big.NewInt(8))},
+		{"Rb", new(big.Rat).SetFrac(RB.n, big.NewInt(8))},
+		{"Qb", new(big.Rat).SetFrac(QB.n, big.NewInt(8))},
+	},
+	FormatBinaryBit: {
+		{"Zib", new(big.Rat).SetFrac(ZiB.n, big.NewInt(8))},
+		{"Yib", new(big.Rat).SetFrac(YiB.n, big.NewInt(8))},
+		{"Rib", new(big.Rat).SetFrac(RiB.n, big.NewInt(8))},
+		{"Qib", new(big.Rat).SetFrac(QiB.n, big.NewInt(8))},
+	},
+}
+
+// bigUnitList returns every unit of the given family, smallest first.
+func bigUnitList(f FormatUnit) []bigUnit {
+	var units []bigUnit
+	for _, u := range unitList(f) {
+		units = append(units, bigUnit{u.String(), u.bytes()})
+	}
+	return append(units, bigUnitsBeyond[f]...)
+}
+
+// bigUnitBytes returns the exact number of bytes in the named unit, including
+// the units beyond exabytes.
+func bigUnitBytes(name string) (*big.Rat, bool) {
+	if mul, ok := unitBytes(name); ok {
+		return mul, true
+	}
+	folded := foldUnit(name)
+	for _, units := range bigUnitsBeyond {
+		for _, u := range units {
+			if u.name == folded {
+				return u.bytes, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// NewBigSize returns the Size as a BigSize.
+func NewBigSize(d Size) BigSize {
+	return BigSize{big.NewInt(int64(d))}
+}
+
+// BigSizeFromInt returns a BigSize of n bytes. The value is copied.
+func BigSizeFromInt(n *big.Int) BigSize {
+	return BigSize{new(big.Int).Set(n)}
+}
+
+// ParseBigSize parses a datasize to BigSize. It accepts everything ParseSize
+// does, as well as the ZB, ZiB, YB, YiB, RB, RiB, QB and QiB units and their
+// bit counterparts, without an upper bound.
+func ParseBigSize(s string) (BigSize, error) {
+	total, err := parseBytes(s, bigUnitBytes)
+	if err != nil {
+		return BigSize{}, err
+	}
+	return BigSize{roundRat(total)}, nil
+}
+
+// int returns the value as a big.Int that must not be modified.
+func (b BigSize) int() *big.Int {
+	if b.n == nil {
+		return new(big.Int)
+	}
+	return b.n
+}
+
+// Int returns the number of bytes as a new big.Int.
+func (b BigSize) Int() *big.Int {
+	return new(big.Int).Set(b.int())
+}
+
+// Size converts the BigSize to a Size. It returns an error matching
+// ErrOverflow if the value does not fit.
+func (b BigSize) Size() (Size, error) {
+	if !b.int().IsInt64() {
+		return 0, fmt.Errorf("%w: %s does not fit in a Size", ErrOverflow, b)
+	}
+	return Size(b.int().Int64()), nil
+}
+
+// Add returns b + o.
+func (b BigSize) Add(o BigSize) BigSize {
+	return BigSize{new(big.Int).Add(b.int(), o.int())}
+}
+
+// Sub returns b - o.
+func (b BigSize) Sub(o BigSize) BigSize {
+	return BigSize{new(big.Int).Sub(b.int(), o.int())}
+}
+
+// Mul returns b * n.
+func (b BigSize) Mul(n int64) BigSize {
+	return BigSize{new(big.Int).Mul(b.int(), big.NewInt(n))}
+}
+
+// Cmp compares b and o and returns -1, 0 or +1.
+func (b BigSize) Cmp(o BigSize) int {
+	return b.int().Cmp(o.int())
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of b.
+func (b BigSize) Sign() int {
+	return b.int().Sign()
+}
+
+// Format implements fmt.Formatter with the same verbs and flags as
+// Size.Format.
+func (b BigSize) Format(f fmt.State, verb rune) {
+	writeFormatted(f, verb, b.formatVerb(f, verb), b.Sign() >= 0)
+}
+
+// formatVerb returns the text of b for a verb of Format, before the flags and
+// width are applied.
+func (b BigSize) formatVerb(f fmt.State, verb rune) string {
+	if verb == 'd' {
+		return b.int().String()
+	}
+	family, ok := formatFamily(verb)
+	if !ok {
+		return b.String()
+	}
+
+	unit := b.bestUnit(family)
+	precision, fixed := f.Precision()
+	if !fixed {
+		precision = defaultPrecision(unit)
+	}
+	return b.formatUnit(unit, precision)
+}
+
+// String returns the default string representation of the BigSize, in binary
+// byte units like Size.String.
+func (b BigSize) String() string {
+	unit := b.bestUnit(FormatBinaryByte)
+	return b.formatUnit(unit, defaultPrecision(unit))
+}
+
+// Exact returns a lossless string representation of the BigSize, so
+// ParseBigSize(b.Exact()) always returns b.
+func (b BigSize) Exact() string {
+	return b.formatUnit(b.bestUnit(FormatBinaryByte), ExactPrecision)
+}
+
+// defaultPrecision returns the precision used when none is given, matching
+// Size: whole bytes and bits, two places otherwise.
+func defaultPrecision(u bigUnit) int {
+	if u.name == "B" || u.name == "b" {
+		return 0
+	}
+	return 2
+}
+
+// bestUnit returns the largest unit of the family whose magnitude does not
+// exceed that of b.
+func (b BigSize) bestUnit(f FormatUnit) bigUnit {
+	return bestBigUnit(new(big.Rat).SetInt(b.int()), f)
+}
+
+// bestBigUnit returns the largest unit of the family whose magnitude does not
+// exceed that of r.
+func bestBigUnit(r *big.Rat, f FormatUnit) bigUnit {
+	abs := new(big.Rat).Abs(r)
+	return islices.LastItemFunc(bigUnitList(f), func(u bigUnit) bool {
+		return u.name == "b" || u.bytes.Cmp(abs) <= 0
+	})
+}
+
+func (b BigSize) formatUnit(u bigUnit, prec int) string {
+	n := b.int()
+	if n.Sign() == 0 {
+		return "0 " + u.name
+	}
+
+	switch u.name {
+	case "B", "b":
+		if u.name == "b" {
+			n = new(big.Int).Lsh(n, 3)
+		}
+		if prec <= 0 {
+			return n.String() + " " + u.name
+		}
+		return fmt.Sprintf("%s.%0*d %s", n, prec, 0, u.name)
+	}
+
+	value := new(big.Rat).SetInt(n)
+	value.Quo(value, u.bytes)
+	if prec != ExactPrecision {
+		return value.FloatString(prec) + " " + u.name
+	}
+
+	// Units are 2^a * 5^b bytes with a, b <= 100.
+	str := strings.TrimRight(value.FloatString(128), "0")
+	return strings.TrimSuffix(str, ".") + " " + u.name
+}
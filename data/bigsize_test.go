@@ -0,0 +1,147 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func bigFromString(t *testing.T, s string) BigSize {
+	t.Helper()
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("bad integer %q", s)
+	}
+	return BigSizeFromInt(n)
+}
+
+func TestParseBigSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"bytes", "42", "42", false},
+		{"size units", "1.5 KiB", "1536", false},
+		{"zettabyte", "1ZB", "1000000000000000000000", false},
+		{"zebibyte", "1 ZiB", "1180591620717411303424", false},
+		{"yottabyte", "2YB", "2000000000000000000000000", false},
+		{"yobibyte", "1YiB", "1208925819614629174706176", false},
+		{"ronnabyte", "1RB", "1000000000000000000000000000", false},
+		{"quettabyte", "1QB", "1000000000000000000000000000000", false},
+		{"lowercase", "1zib", "1180591620717411303424", false},
+		{"zettabit", "8Zb", "1000000000000000000000", false},
+		{"exbibit", "8Eib", "1152921504606846976", false},
+		{"fraction", "1.5ZB", "1500000000000000000000", false},
+		{"compound", "1ZB 1B", "1000000000000000000001", false},
+		{"negative", "-1ZB", "-1000000000000000000000", false},
+		{"past int64", "10EiB", "11529215046068469760", false},
+		{"unknown unit", "1XB", "", true},
+		{"empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBigSize(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Int().String() != tt.want {
+				t.Fatalf("ParseBigSize(%q) = %d, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBigSize_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		fmt  string
+		size BigSize
+		want string
+	}{
+		{"zero", "%s", BigSize{}, "0 B"},
+		{"bytes", "%B", NewBigSize(42), "42 B"},
+		{"binary", "%B", NewBigSize(1536), "1.50 kiB"},
+		{"zebibyte", "%B", ZiB, "1.00 ZiB"},
+		{"yobibyte", "%.1B", YiB.Mul(3), "3.0 YiB"},
+		{"metric", "%M", ZB.Add(ZB.Mul(4)).Sub(ZB.Mul(3)), "2.00 ZB"},
+		{"quettabyte", "%M", QB.Mul(2000), "2000.00 QB"},
+		{"metric bits", "%m", ZB, "8.00 Zb"},
+		{"binary bits", "%b", ZiB, "8.00 Zib"},
+		{"bits", "%b", NewBigSize(1), "8 b"},
+		{"raw", "%d", ZB, "1000000000000000000000"},
+		{"negative", "%B", ZiB.Mul(-2), "-2.00 ZiB"},
+		{"forced sign", "%+M", ZB, "+1.00 ZB"},
+		{"string", "%v", RiB, "1.00 RiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fmt.Sprintf(tt.fmt, tt.size)
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBigSize_RoundTrip(t *testing.T) {
+	sizes := []BigSize{
+		{}, NewBigSize(1), NewBigSize(-1536), ZiB.Add(NewBigSize(1)),
+		QiB.Mul(1000), bigFromString(t, "123456789012345678901234567890"),
+	}
+
+	for _, size := range sizes {
+		for _, s := range []string{size.Exact(), fmt.Sprintf("%d", size)} {
+			got, err := ParseBigSize(s)
+			if err != nil {
+				t.Fatalf("ParseBigSize(%q) unexpected error: %v", s, err)
+			}
+			if got.Cmp(size) != 0 {
+				t.Fatalf("ParseBigSize(%q) = %d, want %d", s, got, size)
+			}
+		}
+	}
+}
+
+func TestBigSize_Size(t *testing.T) {
+	got, err := NewBigSize(-5 * EiB).Size()
+	if err != nil || got != -5*EiB {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	largest := NewBigSize(Size(1<<63 - 1))
+	if _, err := largest.Size(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = largest.Add(NewBigSize(1)).Size()
+	if !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got %v, want ErrOverflow", err)
+	}
+}
+
+func TestBigSize_Immutable(t *testing.T) {
+	n := big.NewInt(10)
+	size := BigSizeFromInt(n)
+	n.SetInt64(20)
+
+	size.Int().SetInt64(30)
+	_ = size.Add(NewBigSize(1))
+
+	if size.Cmp(NewBigSize(10)) != 0 {
+		t.Fatalf("BigSize was modified: %d", size)
+	}
+	if ZiB.Sign() != 1 || ZiB.Int().String() != "1180591620717411303424" {
+		t.Fatalf("ZiB was modified: %d", ZiB)
+	}
+}
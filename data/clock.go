@@ -0,0 +1,22 @@
+package data
+
+import "time"
+
+// Clock tells time for the limiters and meters in this package. Tests inject
+// a fake Clock to make them deterministic.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by package time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
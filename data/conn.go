@@ -0,0 +1,292 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// ThrottleConn returns a connection that writes at no more than up and reads
+// at no more than down, each with its own budget. A limit of zero leaves that
+// direction unlimited.
+//
+// Deadlines keep working: a Read or Write that would wait for its budget past
+// the deadline returns os.ErrDeadlineExceeded once the deadline passes, and
+// changing a deadline wakes the waits in progress. Close also ends them, with
+// net.ErrClosed.
+func ThrottleConn(conn net.Conn, up, down Speed, opts ...Option) net.Conn {
+	o := newOptions(opts)
+	return newThrottledConn(
+		o.ctx, conn, NewLimiter(down, opts...), NewLimiter(up, opts...),
+	)
+}
+
+// throttledConn is a net.Conn whose reads and writes wait on limiters, which
+// may be the same one.
+type throttledConn struct {
+	net.Conn
+	ctx     context.Context
+	down    *Limiter
+	up      *Limiter
+	read    deadline
+	write   deadline
+	onClose func()
+	once    sync.Once
+}
+
+func newThrottledConn(
+	ctx context.Context, conn net.Conn, down, up *Limiter,
+) *throttledConn {
+	return &throttledConn{Conn: conn, ctx: ctx, down: down, up: up}
+}
+
+// Read reads at most one burst from the connection and then waits until the
+// read budget allows the bytes read. An unlimited read reads p whole.
+func (c *throttledConn) Read(p []byte) (int, error) {
+	if len(p) == 0 || c.down.Limit() == 0 {
+		return c.Conn.Read(p)
+	}
+	if chunk := c.down.chunk(); Size(len(p)) > chunk {
+		p = p[:chunk]
+	}
+
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		if werr := c.read.wait(c.ctx, c.down, Size(n)); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// Write waits for the write budget and writes p in chunks of at most one
+// burst. An unlimited write writes p whole.
+func (c *throttledConn) Write(p []byte) (int, error) {
+	if c.up.Limit() == 0 {
+		return c.Conn.Write(p)
+	}
+
+	var written int
+	for len(p) > 0 {
+		chunk := p
+		if size := c.up.chunk(); Size(len(chunk)) > size {
+			chunk = chunk[:size]
+		}
+		err := c.write.wait(c.ctx, c.up, Size(len(chunk)))
+		if err != nil {
+			return written, err
+		}
+
+		n, err := c.Conn.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func (c *throttledConn) SetDeadline(t time.Time) error {
+	c.read.set(t)
+	c.write.set(t)
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *throttledConn) SetReadDeadline(t time.Time) error {
+	c.read.set(t)
+	return c.Conn.SetReadDeadline(t)
+}
+
+func (c *throttledConn) SetWriteDeadline(t time.Time) error {
+	c.write.set(t)
+	return c.Conn.SetWriteDeadline(t)
+}
+
+func (c *throttledConn) Close() error {
+	c.read.close()
+	c.write.close()
+	c.once.Do(func() {
+		if c.onClose != nil {
+			c.onClose()
+		}
+	})
+	return c.Conn.Close()
+}
+
+// errDeadlineChanged cancels a wait so that it restarts with a new deadline.
+var errDeadlineChanged = errors.New("deadline changed")
+
+// deadline is the deadline of one direction of a throttledConn. Setting it, or
+// closing it, cancels the waits in progress.
+type deadline struct {
+	mu      sync.Mutex
+	t       time.Time
+	closed  bool
+	next    int
+	waiting map[int]context.CancelCauseFunc
+}
+
+func (d *deadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.t = t
+	d.cancelLocked(errDeadlineChanged)
+}
+
+func (d *deadline) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closed = true
+	d.cancelLocked(net.ErrClosed)
+}
+
+func (d *deadline) cancelLocked(cause error) {
+	for _, cancel := range d.waiting {
+		cancel(cause)
+	}
+}
+
+// wait waits on l for n bytes within ctx and the deadline. It restarts when
+// the deadline changes.
+func (d *deadline) wait(ctx context.Context, l *Limiter, n Size) error {
+	for {
+		d.mu.Lock()
+		if d.closed {
+			d.mu.Unlock()
+			return net.ErrClosed
+		}
+		waitCtx, cancel := context.WithCancelCause(ctx)
+		if d.waiting == nil {
+			d.waiting = make(map[int]context.CancelCauseFunc)
+		}
+		id := d.next
+		d.next++
+		d.waiting[id] = cancel
+		t := d.t
+		d.mu.Unlock()
+
+		err := waitDeadline(waitCtx, l, n, t)
+
+		d.mu.Lock()
+		delete(d.waiting, id)
+		d.mu.Unlock()
+		cause := context.Cause(waitCtx)
+		cancel(nil)
+
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(cause, errDeadlineChanged):
+			continue
+		case errors.Is(cause, net.ErrClosed):
+			return net.ErrClosed
+		}
+		return err
+	}
+}
+
+// waitDeadline waits on l for n bytes, failing with os.ErrDeadlineExceeded
+// once t passes on the clock of l. A zero t means no deadline.
+func waitDeadline(ctx context.Context, l *Limiter, n Size, t time.Time) error {
+	if t.IsZero() {
+		return l.WaitN(ctx, n)
+	}
+	now := l.clock.Now()
+	if !now.Before(t) {
+		return os.ErrDeadlineExceeded
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go func() {
+		select {
+		case <-l.clock.After(t.Sub(now)):
+			cancel(os.ErrDeadlineExceeded)
+		case <-ctx.Done():
+		}
+	}()
+
+	err := l.WaitN(ctx, n)
+	if err != nil && errors.Is(context.Cause(ctx), os.ErrDeadlineExceeded) {
+		return os.ErrDeadlineExceeded
+	}
+	return err
+}
+
+// ListenerLimits are the limits of a throttled listener. Up limits what the
+// server writes and Down what it reads. A zero limit is unlimited.
+type ListenerLimits struct {
+	// Up and Down cap each connection.
+	Up, Down Speed
+	// TotalUp and TotalDown are shared by all connections, as in Bandwidth.
+	TotalUp, TotalDown Speed
+}
+
+// ThrottledListener is a net.Listener whose connections are throttled like
+// those of ThrottleConn, each within its own limits and all together within
+// the total limits.
+type ThrottledListener struct {
+	net.Listener
+	ctx  context.Context
+	up   *Bandwidth
+	down *Bandwidth
+
+	mu     sync.Mutex
+	limits ListenerLimits
+}
+
+// ThrottleListener returns a listener that throttles the connections it
+// accepts. WithBurst and WithClock apply to the limiter of each connection,
+// and WithContext to its waits.
+func ThrottleListener(
+	l net.Listener, limits ListenerLimits, opts ...Option,
+) *ThrottledListener {
+	return &ThrottledListener{
+		Listener: l,
+		ctx:      newOptions(opts).ctx,
+		up:       NewBandwidth(limits.TotalUp, opts...),
+		down:     NewBandwidth(limits.TotalDown, opts...),
+		limits:   limits,
+	}
+}
+
+// Accept waits for and returns the next connection, throttled.
+func (l *ThrottledListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	up := l.up.NewStream(1, l.limits.Up)
+	down := l.down.NewStream(1, l.limits.Down)
+	l.mu.Unlock()
+
+	c := newThrottledConn(l.ctx, conn, down.limiter, up.limiter)
+	c.onClose = func() {
+		up.Close()
+		down.Close()
+	}
+	return c, nil
+}
+
+// Limits returns the current limits.
+func (l *ThrottledListener) Limits() ListenerLimits {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limits
+}
+
+// SetLimits changes the limits of the listener, including those of the
+// connections already accepted.
+func (l *ThrottledListener) SetLimits(limits ListenerLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits = limits
+	l.up.setCaps(limits.TotalUp, limits.Up)
+	l.down.setCaps(limits.TotalDown, limits.Down)
+}
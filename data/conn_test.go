@@ -0,0 +1,215 @@
+package data
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestThrottleConn_Budgets(t *testing.T) {
+	clock := newFakeClock(true)
+	client, server := net.Pipe()
+	defer server.Close()
+	conn := ThrottleConn(client, 100, 1000, WithClock(clock))
+	defer conn.Close()
+
+	up := bytes.Repeat([]byte("u"), 400)
+	go conn.Write(up)
+	got := make([]byte, len(up))
+	if _, err := io.ReadFull(server, got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := clock.Elapsed(); elapsed != 3*time.Second {
+		t.Fatalf("upload took %v, want 3s", elapsed)
+	}
+
+	down := bytes.Repeat([]byte("d"), 4000)
+	go server.Write(down)
+	got = make([]byte, len(down))
+	if _, err := io.ReadFull(conn, got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := clock.Elapsed(); elapsed != 6*time.Second {
+		t.Fatalf("download took %v, want 3s", elapsed-3*time.Second)
+	}
+}
+
+// blockedConn returns a connection whose writes wait for a second per byte on
+// clock, with the other end drained.
+func blockedConn(t *testing.T, clock Clock) net.Conn {
+	client, server := net.Pipe()
+	go io.Copy(io.Discard, server)
+	t.Cleanup(func() { server.Close() })
+
+	conn := ThrottleConn(client, 1, 0, WithBurst(1), WithClock(clock))
+	if _, err := conn.Write([]byte("x")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return conn
+}
+
+func TestThrottleConn_Deadline(t *testing.T) {
+	clock := newFakeClock(false)
+	conn := blockedConn(t, clock)
+	defer conn.Close()
+
+	// The deadline is on the fake clock, long past by the system clock.
+	conn.SetWriteDeadline(clock.Now().Add(500 * time.Millisecond))
+	done := make(chan error)
+	go func() {
+		_, err := conn.Write([]byte("data"))
+		done <- err
+	}()
+	clock.waitTimers(t, 2)
+	select {
+	case err := <-done:
+		t.Fatalf("write returned %v before the deadline", err)
+	default:
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	err := <-done
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got %v, want os.ErrDeadlineExceeded", err)
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("%v is not a timeout", err)
+	}
+}
+
+func TestThrottleConn_WakeWaits(t *testing.T) {
+	tests := []struct {
+		name string
+		wake func(net.Conn, Clock)
+		want error
+	}{
+		{
+			"deadline",
+			func(c net.Conn, clock Clock) { c.SetDeadline(clock.Now()) },
+			os.ErrDeadlineExceeded,
+		},
+		{"close", func(c net.Conn, _ Clock) { c.Close() }, net.ErrClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock(false)
+			conn := blockedConn(t, clock)
+			defer conn.Close()
+
+			done := make(chan error)
+			go func() {
+				_, err := conn.Write([]byte("data"))
+				done <- err
+			}()
+			clock.waitTimers(t, 1)
+			tt.wake(conn, clock)
+
+			select {
+			case err := <-done:
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("got %v, want %v", err, tt.want)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("write still waiting")
+			}
+		})
+	}
+}
+
+// countingConn is a net.Conn that reads and writes through a countingIO.
+type countingConn struct {
+	net.Conn
+	rw *countingIO
+}
+
+func (c countingConn) Read(p []byte) (int, error)  { return c.rw.Read(p) }
+func (c countingConn) Write(p []byte) (int, error) { return c.rw.Write(p) }
+
+func TestThrottleConn_Unlimited(t *testing.T) {
+	data := bytes.Repeat([]byte("real"), 250)
+	rw := &countingIO{r: bytes.NewReader(data)}
+	conn := ThrottleConn(countingConn{rw: rw}, 0, 0, WithBurst(10))
+
+	if n, err := conn.Read(make([]byte, len(data))); n != len(data) {
+		t.Fatalf("Read() = %d, %v, want %d, nil", n, err, len(data))
+	}
+	if n, err := conn.Write(data); n != len(data) || err != nil {
+		t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(data))
+	}
+	if rw.reads != 1 || rw.writes != 1 {
+		t.Fatalf("made %d reads and %d writes, want 1 each", rw.reads, rw.writes)
+	}
+}
+
+// pipeListener is a net.Listener that accepts the server ends of pipes.
+type pipeListener chan net.Conn
+
+func (l pipeListener) Accept() (net.Conn, error) {
+	conn, ok := <-l
+	if !ok {
+		return nil, net.ErrClosed
+	}
+	return conn, nil
+}
+
+func (l pipeListener) Close() error   { return nil }
+func (l pipeListener) Addr() net.Addr { return nil }
+
+func (l pipeListener) dial() {
+	_, server := net.Pipe()
+	l <- server
+}
+
+func TestThrottleListener(t *testing.T) {
+	pipes := make(pipeListener, 2)
+	l := ThrottleListener(pipes, ListenerLimits{Up: 800, TotalUp: 1000})
+	pipes.dial()
+	pipes.dial()
+
+	var conns []*throttledConn
+	for range 2 {
+		conn, err := l.Accept()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		conns = append(conns, conn.(*throttledConn))
+	}
+
+	limits := func() []Speed {
+		var got []Speed
+		for _, c := range conns {
+			got = append(got, c.up.Limit(), c.down.Limit())
+		}
+		return got
+	}
+	steps := []struct {
+		name   string
+		change func()
+		want   []Speed
+	}{
+		{"shared", func() {}, []Speed{500, 0, 500, 0}},
+		{"closed", func() { conns[1].Close() }, []Speed{800, 0, 0, 0}},
+		{
+			"changed",
+			func() { l.SetLimits(ListenerLimits{Up: 300, Down: 200}) },
+			[]Speed{300, 200, 0, 0},
+		},
+	}
+	for _, step := range steps {
+		step.change()
+		if got := limits(); !slices.Equal(got, step.want) {
+			t.Fatalf("%s: limits = %v, want %v", step.name, got, step.want)
+		}
+	}
+
+	if got := l.Limits(); got != (ListenerLimits{Up: 300, Down: 200}) {
+		t.Fatalf("Limits() = %+v", got)
+	}
+}
@@ -0,0 +1,106 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// maxExponent bounds the exponent accepted by parseDecimal so that inputs such
+// as "1e999999999" cannot make big.Rat allocate an enormous power of ten.
+const maxExponent = 100
+
+// parseDecimal parses a decimal number with an optional sign, fractional part
+// and exponent ("42", "-1.5", ".25", "2e3", "1.5E-2") into an exact rational.
+func parseDecimal(s string) (*big.Rat, error) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+
+	digits := 0
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return nil, fmt.Errorf("invalid number: %q", s)
+	}
+
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent in number: %q", s)
+		}
+		if exp > maxExponent || exp < -maxExponent {
+			return nil, fmt.Errorf("exponent out of range: %q", s)
+		}
+		i = len(s)
+	}
+
+	if i != len(s) {
+		return nil, fmt.Errorf("invalid number: %q", s)
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid number: %q", s)
+	}
+	return r, nil
+}
+
+// scanNumber returns the length of the decimal number at the start of s, as
+// accepted by parseDecimal without a sign. An exponent is only consumed when
+// it is followed by digits, so "1EB" is one exabyte rather than a bad exponent.
+func scanNumber(s string) int {
+	i, digits := 0, 0
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return 0
+	}
+
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && isDigit(s[j]) {
+			for j < len(s) && isDigit(s[j]) {
+				j++
+			}
+			i = j
+		}
+	}
+	return i
+}
+
+// roundRat rounds r to the nearest integer. Halves are rounded away from zero.
+func roundRat(r *big.Rat) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	m.Abs(m).Lsh(m, 1)
+	if m.Cmp(r.Denom()) >= 0 {
+		if r.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,108 @@
+package data
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"integer", "42", "42", false},
+		{"signed integer", "-42", "-42", false},
+		{"plus sign", "+7", "7", false},
+		{"fraction", "1.5", "3/2", false},
+		{"leading dot", ".25", "1/4", false},
+		{"trailing dot", "3.", "3", false},
+		{"exponent", "2e3", "2000", false},
+		{"negative exponent", "1.5E-2", "3/200", false},
+		{"signed exponent", "1e+2", "100", false},
+
+		{"empty", "", "", true},
+		{"sign only", "-", "", true},
+		{"dot only", ".", "", true},
+		{"fraction form rejected", "1/2", "", true},
+		{"hex rejected", "0x10", "", true},
+		{"missing exponent", "1e", "", true},
+		{"huge exponent", "1e999999999", "", true},
+		{"double dot", "1.2.3", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDecimal(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.RatString() != tt.want {
+				t.Fatalf("parseDecimal(%q) = %s, want %s",
+					tt.input, got.RatString(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		name string
+		num  int64
+		den  int64
+		want int64
+	}{
+		{"exact", 4, 2, 2},
+		{"round down", 1, 3, 0},
+		{"round up", 2, 3, 1},
+		{"half away from zero", 1, 2, 1},
+		{"negative half away from zero", -1, 2, -1},
+		{"negative round toward zero", -1, 3, 0},
+		{"negative round away", -5, 3, -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := roundRat(big.NewRat(tt.num, tt.den))
+			if got.Int64() != tt.want {
+				t.Fatalf("roundRat(%d/%d) = %v, want %d",
+					tt.num, tt.den, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"42KiB", 2},
+		{"1.5 GiB", 3},
+		{".5B", 2},
+		{"2e3kB", 3},
+		{"1e-3MB", 4},
+		{"1EB", 1},
+		{"1e", 1},
+		{"1e+", 1},
+		{"KB", 0},
+		{".", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := scanNumber(tt.input)
+			if got != tt.want {
+				t.Fatalf("scanNumber(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler. The Size is written in the
+// lossless form of Exact, such as "1.5 kiB". Use RawSize for a number of bytes.
+func (d Size) MarshalText() ([]byte, error) {
+	return []byte(d.Exact()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts anything
+// ParseSize does, including a raw byte count.
+func (d *Size) UnmarshalText(text []byte) error {
+	size, err := ParseSize(string(text))
+	if err != nil {
+		return err
+	}
+	*d = size
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The Size is written as a JSON string
+// like MarshalText.
+func (d Size) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Exact())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON number of bytes
+// or a JSON string understood by ParseSize. A JSON null leaves the Size
+// unchanged.
+func (d *Size) UnmarshalJSON(data []byte) error {
+	text, err := jsonText(data)
+	if err != nil || text == nil {
+		return err
+	}
+	return d.UnmarshalText(text)
+}
+
+// MarshalText implements encoding.TextMarshaler. The Speed is written in the
+// lossless form of Exact, such as "1.5 kiB/s". Use RawSpeed for a number of
+// bytes per second.
+func (s Speed) MarshalText() ([]byte, error) {
+	return []byte(s.Exact()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts anything
+// ParseSpeed does, as well as a raw number of bytes per second.
+func (s *Speed) UnmarshalText(text []byte) error {
+	str := strings.TrimSpace(string(text))
+	num, err := parseDecimal(str)
+	if err != nil {
+		speed, err := ParseSpeed(str)
+		if err != nil {
+			return err
+		}
+		*s = speed
+		return nil
+	}
+
+	n := roundRat(num)
+	if n.Sign() < 0 {
+		return fmt.Errorf("negative dataspeed: %q", text)
+	}
+	if !n.IsUint64() {
+		return fmt.Errorf("%w: dataspeed overflows uint64: %q", ErrOverflow, text)
+	}
+	*s = Speed(n.Uint64())
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The Speed is written as a JSON string
+// like MarshalText.
+func (s Speed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Exact())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON number of bytes
+// per second or a JSON string understood by ParseSpeed. A JSON null leaves the
+// Speed unchanged.
+func (s *Speed) UnmarshalJSON(data []byte) error {
+	text, err := jsonText(data)
+	if err != nil || text == nil {
+		return err
+	}
+	return s.UnmarshalText(text)
+}
+
+// jsonText returns the text of a JSON string or number. It returns nil for a
+// JSON null.
+func jsonText(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return nil, err
+		}
+		return []byte(str), nil
+	}
+
+	if _, err := parseDecimal(string(data)); err != nil {
+		return nil, fmt.Errorf("expected JSON string or number, got %s", data)
+	}
+	return data, nil
+}
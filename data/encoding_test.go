@@ -0,0 +1,195 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSize_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		size any
+		want string
+	}{
+		{"string zero", Size(0), `"0 B"`},
+		{"string bytes", Size(42), `"42 B"`},
+		{"string lossless", GiB + 1, `"1.000000000931322574615478515625 GiB"`},
+		{"number", RawSize(1536), `1536`},
+		{"negative number", RawSize(-1), `-1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.size)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+
+			back := reflect.New(reflect.TypeOf(tt.size))
+			if err := json.Unmarshal(got, back.Interface()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if back.Elem().Interface() != tt.size {
+				t.Fatalf("round trip got %v, want %v", back.Elem(), tt.size)
+			}
+		})
+	}
+}
+
+func TestSize_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Size
+		wantErr bool
+	}{
+		{"number", `1024`, KiB, false},
+		{"negative number", `-1`, -1, false},
+		{"float number", `1.5e3`, 1500, false},
+		{"string", `"1.5 GiB"`, GiB + 512*MiB, false},
+		{"plain number string", `"42"`, 42, false},
+		{"null keeps value", `null`, 7, false},
+		{"bool", `true`, 0, true},
+		{"object", `{}`, 0, true},
+		{"bad string", `"10XB"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Size(7)
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSize_TextMarshaler(t *testing.T) {
+	var config struct {
+		Limit Size    `json:"limit"`
+		Quota RawSize `json:"quota"`
+	}
+	// Map keys and values both go through the text interfaces.
+	sizes := map[Size]Size{KiB: MiB}
+
+	out, err := json.Marshal(sizes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `{"1 kiB":"1 MiB"}` {
+		t.Fatalf("got %s", out)
+	}
+
+	in := `{"limit":"10 MiB","quota":"1 MiB"}`
+	if err := json.Unmarshal([]byte(in), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Limit != 10*MiB || config.Quota != RawSize(MiB) {
+		t.Fatalf("got %v and %v, want %v and %v",
+			config.Limit, config.Quota, 10*MiB, MiB)
+	}
+
+	// The encoding follows the type of each field.
+	out, err = json.Marshal(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"limit":"10 MiB","quota":1048576}`; string(out) != want {
+		t.Fatalf("got %s, want %s", out, want)
+	}
+}
+
+func TestSpeed_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		speed any
+		want  string
+	}{
+		{"string zero", Speed(0), `"0 B/s"`},
+		{"string", Speed(1536), `"1.5 kiB/s"`},
+		{"number", RawSpeed(1536), `1536`},
+		{"max number", RawSpeed(1<<64 - 1), `18446744073709551615`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.speed)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+
+			back := reflect.New(reflect.TypeOf(tt.speed))
+			if err := json.Unmarshal(got, back.Interface()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if back.Elem().Interface() != tt.speed {
+				t.Fatalf("round trip got %v, want %v", back.Elem(), tt.speed)
+			}
+		})
+	}
+}
+
+func TestSpeed_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Speed
+		wantErr bool
+	}{
+		{"number", "1024", Speed(KiB), false},
+		{"spaced number", " 1024 ", Speed(KiB), false},
+		{"speed", "10 MB/s", Speed(10 * MB), false},
+		{"speed per millisecond", "1B/ms", 1000, false},
+		{"max", "18446744073709551615", Speed(1<<64 - 1), false},
+		{"overflow", "18446744073709551616", 0, true},
+		{"negative", "-1", 0, true},
+		{"size without time", "10 MB", 0, true},
+		{"empty", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Speed
+			err := got.UnmarshalText([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeed_UnmarshalText_Overflow(t *testing.T) {
+	for _, input := range []string{"18446744073709551616", "20 EB/s"} {
+		var s Speed
+		if err := s.UnmarshalText([]byte(input)); !errors.Is(err, ErrOverflow) {
+			t.Errorf("UnmarshalText(%q) = %v, want ErrOverflow", input, err)
+		}
+	}
+}
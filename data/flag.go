@@ -0,0 +1,59 @@
+package data
+
+import "flag"
+
+// Set implements flag.Value using ParseSize.
+func (d *Size) Set(s string) error {
+	return d.UnmarshalText([]byte(s))
+}
+
+// Set implements flag.Value. It accepts anything ParseSpeed does, as well as a
+// raw number of bytes per second.
+func (s *Speed) Set(str string) error {
+	return s.UnmarshalText([]byte(str))
+}
+
+// SizeVar defines a Size flag with the specified name, default value, and usage
+// string on fs, such as --max-upload=1.5GiB. The argument p points to a Size
+// variable in which to store the value of the flag. A nil fs defines the flag
+// on flag.CommandLine.
+func SizeVar(fs *flag.FlagSet, p *Size, name string, def Size, usage string) {
+	*p = def
+	flagSet(fs).Var(p, name, usage)
+}
+
+// SizeFlag defines a Size flag with the specified name, default value, and
+// usage string on fs. The return value is the address of a Size variable that
+// stores the value of the flag. A nil fs defines the flag on flag.CommandLine.
+func SizeFlag(fs *flag.FlagSet, name string, def Size, usage string) *Size {
+	p := new(Size)
+	SizeVar(fs, p, name, def, usage)
+	return p
+}
+
+// SpeedVar defines a Speed flag with the specified name, default value, and
+// usage string on fs, such as --limit=10Mbps. The argument p points to a Speed
+// variable in which to store the value of the flag. A nil fs defines the flag
+// on flag.CommandLine.
+func SpeedVar(
+	fs *flag.FlagSet, p *Speed, name string, def Speed, usage string,
+) {
+	*p = def
+	flagSet(fs).Var(p, name, usage)
+}
+
+// SpeedFlag defines a Speed flag with the specified name, default value, and
+// usage string on fs. The return value is the address of a Speed variable that
+// stores the value of the flag. A nil fs defines the flag on flag.CommandLine.
+func SpeedFlag(fs *flag.FlagSet, name string, def Speed, usage string) *Speed {
+	p := new(Speed)
+	SpeedVar(fs, p, name, def, usage)
+	return p
+}
+
+func flagSet(fs *flag.FlagSet) *flag.FlagSet {
+	if fs == nil {
+		return flag.CommandLine
+	}
+	return fs
+}
@@ -0,0 +1,102 @@
+package data
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestSizeVar(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    Size
+		wantErr bool
+	}{
+		{"default", nil, 10 * MiB, false},
+		{"binary", []string{"--max-upload=1.5GiB"}, GiB + 512*MiB, false},
+		{"separate argument", []string{"-max-upload", "2 MB"}, 2 * MB, false},
+		{"raw bytes", []string{"--max-upload=42"}, 42, false},
+		{"invalid", []string{"--max-upload=lots"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+
+			var got Size
+			SizeVar(fs, &got, "max-upload", 10*MiB, "upload limit")
+
+			err := fs.Parse(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeedFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    Speed
+		wantErr bool
+	}{
+		{"default", nil, Speed(MB), false},
+		{"bits per second", []string{"--limit=10Mbps"}, Speed(10 * Mb), false},
+		{"slash form", []string{"--limit=1 KiB/s"}, Speed(KiB), false},
+		{"raw number", []string{"--limit=100"}, 100, false},
+		{"negative", []string{"--limit=-1"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+
+			got := SpeedFlag(fs, "limit", Speed(MB), "bandwidth limit")
+
+			err := fs.Parse(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", *got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Fatalf("got %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlagDefaults(t *testing.T) {
+	var out bytes.Buffer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&out)
+
+	SizeFlag(fs, "size", 1536, "a size")
+	SpeedFlag(fs, "speed", 0, "a speed")
+	fs.PrintDefaults()
+
+	if !strings.Contains(out.String(), "(default 1.50 kiB)") {
+		t.Fatalf("missing size default in %q", out.String())
+	}
+	if strings.Contains(out.String(), "(default 0 B/s)") {
+		t.Fatalf("zero speed default should be omitted in %q", out.String())
+	}
+}
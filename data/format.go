@@ -0,0 +1,38 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Nadim147c/real-go/internal/format"
+)
+
+// writeFormatted writes text, a value formatted with its unit, to f for a
+// Format method. The flags and width of f apply to the whole text:
+//   - '+' and ' ' put a sign before non-negative values
+//   - '#' selects the compact ASCII form, without a space before the unit and
+//     with "u" for "µ", such as "1.50MiB/s"
+//   - %q quotes the text
+//   - the width pads the text, on the right with '-'
+func writeFormatted(f fmt.State, verb rune, text string, nonNegative bool) {
+	if f.Flag('#') {
+		text = strings.Replace(text, " ", "", 1)
+		text = strings.ReplaceAll(text, "µ", "u")
+	}
+	format.Write(f, verb, text, nonNegative)
+}
+
+// formatFamily returns the family of units selected by a verb of Format.
+func formatFamily(verb rune) (FormatUnit, bool) {
+	switch verb {
+	case 'B':
+		return FormatBinaryByte, true
+	case 'b':
+		return FormatBinaryBit, true
+	case 'M':
+		return FormatMetricByte, true
+	case 'm':
+		return FormatMetricBit, true
+	}
+	return 0, false
+}
@@ -0,0 +1,62 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormat_Flags(t *testing.T) {
+	third, _ := NewPreciseSpeed(1, 3*time.Second)
+
+	tests := []struct {
+		name  string
+		value any
+		fmt   string
+		want  string
+	}{
+		{"width", 1536 * KiB, "%10.1B|", "   1.5 MiB|"},
+		{"left", 1536 * KiB, "%-10.1B|", "1.5 MiB   |"},
+		{"width smaller than text", 1536 * KiB, "%2.1B", "1.5 MiB"},
+		{"plus", 1536 * KiB, "%+.1B", "+1.5 MiB"},
+		{"plus negative", -1536 * KiB, "%+.1B", "-1.5 MiB"},
+		{"space", 1536 * KiB, "% .1B|", " 1.5 MiB|"},
+		{"space negative", -1536 * KiB, "% .1B|", "-1.5 MiB|"},
+		{"plus and width", 1536 * KiB, "%+9.1B|", " +1.5 MiB|"},
+		{"compact", 1536 * KiB, "%#.1M", "1.6MB"},
+		{"compact bytes", Size(12), "%#B", "12B"},
+		{"value", 1536 * KiB, "%v", "1.50 MiB"},
+		{"value width", 1536 * KiB, "%-9v|", "1.50 MiB |"},
+		{"string", 1536 * KiB, "%10s|", "  1.50 MiB|"},
+		{"quoted", 1536 * KiB, "%q", `"1.50 MiB"`},
+		{"quoted width", Size(12), "%8q|", `  "12 B"|`},
+		{"raw", Size(1024), "%+8d|", "   +1024|"},
+
+		{"speed width", Speed(MiB), "%12B|", "  1.00 MiB/s|"},
+		{"speed left compact", Speed(1536 * KB), "%#-10.1M|", "1.5MB/s   |"},
+		{"speed raw", Speed(1024), "%-8d|", "1024/s  |"},
+		{"speed quoted", Speed(KiB), "%q", `"1.00 kiB/s"`},
+		{"speed beyond int64", Speed(math.MaxUint64), "%.1B", "16.0 EiB/s"},
+		{"speed beyond int64 string", Speed(math.MaxUint64), "%v", "16.00 EiB/s"},
+
+		{"rate", Rate(-MiB), "%12.1B|", "  -1.0 MiB/s|"},
+		{"rate plus", Rate(MiB), "%+.1B", "+1.0 MiB/s"},
+
+		{"big size", NewBigSize(3 * GiB).Mul(1 << 40), "%-12.1B|", "3.0 ZiB     |"},
+		{"big size plus", NewBigSize(KiB), "%+B", "+1.00 kiB"},
+
+		{"precise", third, "%10v|", "  0.33 B/s|"},
+		{"precise compact", third, "%#.1B", "0.3B/s"},
+		{"per", Speed(150 * KB).Per(time.Hour), "%-12.0M|", "540 MB/h    |"},
+		{"per micro", Speed(MB).Per(time.Microsecond), "%#.0M", "1B/us"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.fmt, tt.value); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.fmt, got, tt.want)
+			}
+		})
+	}
+}
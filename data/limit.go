@@ -0,0 +1,265 @@
+package data
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket that limits a flow of bytes to a Speed. The bucket
+// fills at the limit and holds at most the burst, so after an idle period up to
+// burst bytes pass at once.
+//
+// A limit of zero means unlimited. The default burst is one second's worth of
+// data at the limit. A Limiter is safe for concurrent use, and its limit and
+// burst may be changed while other goroutines wait on it.
+type Limiter struct {
+	mu      sync.Mutex
+	clock   Clock
+	limit   Speed
+	burst   Size // explicit burst; zero follows the limit
+	tokens  Size // negative after a wait for more than the burst
+	last    time.Time
+	changed chan struct{}
+}
+
+// NewLimiter returns a Limiter for the limit. The bucket starts full.
+func NewLimiter(limit Speed, opts ...Option) *Limiter {
+	o := newOptions(opts)
+	l := &Limiter{
+		clock:   o.clock,
+		limit:   limit,
+		burst:   max(o.burst, 0),
+		last:    o.clock.Now(),
+		changed: make(chan struct{}),
+	}
+	l.tokens = l.burstLocked()
+	return l
+}
+
+// Limit returns the current limit.
+func (l *Limiter) Limit() Speed {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// Burst returns the current burst.
+func (l *Limiter) Burst() Size {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.burstLocked()
+}
+
+// SetLimit changes the limit. Waiting callers are woken to use the new limit.
+func (l *Limiter) SetLimit(limit Speed) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refillLocked(l.clock.Now())
+	unlimited := l.limit == 0
+	l.limit = limit
+	if unlimited {
+		// Nothing was owed while unlimited, so start with a full bucket.
+		l.tokens = l.burstLocked()
+	}
+	l.tokens = min(l.tokens, l.burstLocked())
+	l.notifyLocked()
+}
+
+// SetBurst changes the burst. A burst of zero or less restores the default of
+// one second's worth of data. Waiting callers are woken to use the new burst.
+func (l *Limiter) SetBurst(burst Size) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refillLocked(l.clock.Now())
+	l.burst = max(burst, 0)
+	l.tokens = min(l.tokens, l.burstLocked())
+	l.notifyLocked()
+}
+
+// WaitN blocks until n bytes may pass, or until ctx is done, in which case it
+// returns the context's error. Waits for more than the burst are allowed: they
+// start once the bucket is full and leave it in debt, which later callers pay
+// off.
+func (l *Limiter) WaitN(ctx context.Context, n Size) error {
+	if n <= 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	for {
+		if err := ctx.Err(); err != nil {
+			l.mu.Unlock()
+			return err
+		}
+
+		if l.limit == 0 {
+			l.mu.Unlock()
+			return nil
+		}
+
+		now := l.clock.Now()
+		l.refillLocked(now)
+		need := min(n, l.burstLocked())
+		if l.tokens >= need {
+			l.tokens -= n
+			l.mu.Unlock()
+			return nil
+		}
+
+		wait := l.limit.TimeFor(need - l.tokens)
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		case <-l.clock.After(wait):
+		}
+		l.mu.Lock()
+	}
+}
+
+// burstLocked returns the burst in effect. The caller must hold l.mu.
+func (l *Limiter) burstLocked() Size {
+	if l.burst > 0 {
+		return l.burst
+	}
+	if l.limit > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return max(Size(l.limit), 1)
+}
+
+// chunk returns the most bytes a reader or writer passes in one call: one
+// burst, or any amount while the limit is zero.
+func (l *Limiter) chunk() Size {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit == 0 {
+		return math.MaxInt64
+	}
+	return l.burstLocked()
+}
+
+// refillLocked adds the tokens earned since the last refill. Only the time
+// that whole tokens account for is consumed, so no fraction of a byte is lost
+// between calls. The caller must hold l.mu.
+func (l *Limiter) refillLocked(now time.Time) {
+	elapsed := now.Sub(l.last)
+	if elapsed <= 0 {
+		return
+	}
+	if l.limit == 0 {
+		l.last = now
+		return
+	}
+
+	earned := l.limit.AmountIn(elapsed)
+	burst := l.burstLocked()
+	if earned >= burst-l.tokens {
+		l.tokens = burst
+		l.last = now
+		return
+	}
+	l.tokens += earned
+	l.last = l.last.Add(l.limit.TimeFor(earned))
+}
+
+// notifyLocked wakes all waiters. The caller must hold l.mu.
+func (l *Limiter) notifyLocked() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// LimitedReader is an io.Reader that reads from an underlying reader no faster
+// than its Limiter allows. The embedded Limiter may be adjusted while reading.
+type LimitedReader struct {
+	*Limiter
+	r   io.Reader
+	ctx context.Context
+}
+
+// NewLimitedReader returns a reader that reads from r at no more than limit.
+// A limit of zero reads at full speed until the limit is set.
+func NewLimitedReader(
+	r io.Reader, limit Speed, opts ...Option,
+) *LimitedReader {
+	return &LimitedReader{
+		Limiter: NewLimiter(limit, opts...),
+		r:       r,
+		ctx:     newOptions(opts).ctx,
+	}
+}
+
+// Read reads at most one burst from the underlying reader and then waits until
+// the limit allows the bytes read. While the limit is zero, Read passes p to
+// the underlying reader whole. If the wait is cancelled, Read returns the
+// bytes read along with the context's error.
+func (lr *LimitedReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return lr.r.Read(p)
+	}
+	if err := lr.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if chunk := lr.chunk(); Size(len(p)) > chunk {
+		p = p[:chunk]
+	}
+	n, err := lr.r.Read(p)
+	if n > 0 {
+		if werr := lr.WaitN(lr.ctx, Size(n)); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// LimitedWriter is an io.Writer that writes to an underlying writer no faster
+// than its Limiter allows. The embedded Limiter may be adjusted while writing.
+type LimitedWriter struct {
+	*Limiter
+	w   io.Writer
+	ctx context.Context
+}
+
+// NewLimitedWriter returns a writer that writes to w at no more than limit.
+// A limit of zero writes at full speed until the limit is set.
+func NewLimitedWriter(
+	w io.Writer, limit Speed, opts ...Option,
+) *LimitedWriter {
+	return &LimitedWriter{
+		Limiter: NewLimiter(limit, opts...),
+		w:       w,
+		ctx:     newOptions(opts).ctx,
+	}
+}
+
+// Write waits for the limit and writes p in chunks of at most one burst, or in
+// one call while the limit is zero. It returns the number of bytes written and
+// stops at the first error, including the context's error when a wait is
+// cancelled.
+func (lw *LimitedWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		chunk := p
+		if size := lw.chunk(); Size(len(chunk)) > size {
+			chunk = chunk[:size]
+		}
+		if err := lw.WaitN(lw.ctx, Size(len(chunk))); err != nil {
+			return written, err
+		}
+
+		n, err := lw.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
@@ -0,0 +1,291 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock for tests. With auto set, After advances the time by
+// the duration and fires at once; otherwise timers fire on Advance.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	auto   bool
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock(auto bool) *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0), auto: auto}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if c.auto {
+		c.now = c.now.Add(max(d, 0))
+	}
+	if c.auto || d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, fakeTimer{c.now.Add(d), ch})
+	return ch
+}
+
+// Advance moves the time forward and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.c <- c.now
+	}
+	c.timers = pending
+}
+
+// Elapsed returns the time since the clock was created.
+func (c *fakeClock) Elapsed() time.Duration {
+	return c.Now().Sub(time.Unix(0, 0))
+}
+
+// waitTimers blocks until n timers are pending.
+func (c *fakeClock) waitTimers(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		got := len(c.timers)
+		c.mu.Unlock()
+		if got == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d pending timers, want %d", got, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLimiter_WaitN(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit Speed
+		burst Size
+		waits []Size
+		want  time.Duration
+	}{
+		{"within burst", 100, 0, []Size{40, 60}, 0},
+		{"refill", 100, 0, []Size{100, 50}, 500 * time.Millisecond},
+		{"steady", 100, 0, []Size{100, 100, 100, 100}, 3 * time.Second},
+		{"explicit burst", 100, 10, []Size{10, 10, 10}, 200 * time.Millisecond},
+		{"more than burst", 100, 0, []Size{250, 1}, 1510 * time.Millisecond},
+		{"bits", Speed(KB), 0, []Size{2 * KB, KB}, 2 * time.Second},
+		{"unlimited", 0, 0, []Size{EB, EB}, 0},
+		{"nothing", 1, 0, []Size{1, 0, -5}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock(true)
+			l := NewLimiter(tt.limit, WithBurst(tt.burst), WithClock(clock))
+			for _, n := range tt.waits {
+				if err := l.WaitN(context.Background(), n); err != nil {
+					t.Fatalf("WaitN(%d): unexpected error: %v", n, err)
+				}
+			}
+			if got := clock.Elapsed(); got != tt.want {
+				t.Errorf("took %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiter_Burst(t *testing.T) {
+	l := NewLimiter(Speed(MB))
+	if got := l.Burst(); got != MB {
+		t.Fatalf("default burst = %d, want %d", got, MB)
+	}
+
+	l.SetBurst(KB)
+	l.SetLimit(Speed(GB))
+	if got := l.Burst(); got != KB {
+		t.Fatalf("explicit burst = %d, want %d", got, KB)
+	}
+
+	l.SetBurst(0)
+	if got := l.Burst(); got != GB {
+		t.Fatalf("restored burst = %d, want %d", got, GB)
+	}
+}
+
+func TestLimiter_WaitNCancel(t *testing.T) {
+	clock := newFakeClock(false)
+	l := NewLimiter(1, WithClock(clock))
+	if err := l.WaitN(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- l.WaitN(ctx, 1) }()
+
+	clock.waitTimers(t, 1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+
+	if err := l.WaitN(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+}
+
+func TestLimiter_SetLimitWakesWaiters(t *testing.T) {
+	clock := newFakeClock(false)
+	l := NewLimiter(1, WithBurst(10), WithClock(clock))
+	if err := l.WaitN(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() { done <- l.WaitN(context.Background(), 10) }()
+	clock.waitTimers(t, 1) // 10s at the old limit
+
+	l.SetLimit(Speed(KB))
+	clock.waitTimers(t, 2) // 10ms at the new limit
+	clock.Advance(10 * time.Millisecond)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLimitedReader(t *testing.T) {
+	clock := newFakeClock(true)
+	data := bytes.Repeat([]byte("real"), 250)
+	r := NewLimitedReader(bytes.NewReader(data), 100, WithClock(clock))
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("read %d bytes, want %d", len(got), len(data))
+	}
+	// The first 100 bytes come from the full bucket.
+	if elapsed := clock.Elapsed(); elapsed != 9*time.Second {
+		t.Fatalf("took %v, want 9s", elapsed)
+	}
+}
+
+func TestLimitedWriter(t *testing.T) {
+	clock := newFakeClock(true)
+	data := bytes.Repeat([]byte("real"), 250)
+	var buf bytes.Buffer
+	w := NewLimitedWriter(&buf, 100, WithBurst(250), WithClock(clock))
+
+	n, err := w.Write(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != len(data) || !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("wrote %d bytes, want %d", n, len(data))
+	}
+	if elapsed := clock.Elapsed(); elapsed != 7500*time.Millisecond {
+		t.Fatalf("took %v, want 7.5s", elapsed)
+	}
+
+	w.SetLimit(0)
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := clock.Elapsed(); elapsed != 7500*time.Millisecond {
+		t.Fatalf("unlimited write took %v", elapsed-7500*time.Millisecond)
+	}
+}
+
+func TestLimited_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	opts := []Option{WithContext(ctx), WithClock(newFakeClock(true))}
+
+	r := NewLimitedReader(bytes.NewReader([]byte("data")), 1, opts...)
+	if n, err := r.Read(make([]byte, 4)); n != 0 || err != context.Canceled {
+		t.Errorf("Read() = %d, %v, want 0, context.Canceled", n, err)
+	}
+
+	w := NewLimitedWriter(io.Discard, 1, opts...)
+	if n, err := w.Write([]byte("data")); n != 0 || err != context.Canceled {
+		t.Errorf("Write() = %d, %v, want 0, context.Canceled", n, err)
+	}
+}
+
+// countingIO counts the Read and Write calls that reach it.
+type countingIO struct {
+	r      io.Reader
+	reads  int
+	writes int
+}
+
+func (c *countingIO) Read(p []byte) (int, error) {
+	c.reads++
+	return c.r.Read(p)
+}
+
+func (c *countingIO) Write(p []byte) (int, error) {
+	c.writes++
+	return len(p), nil
+}
+
+func TestLimited_Unlimited(t *testing.T) {
+	data := bytes.Repeat([]byte("real"), 250)
+	opts := []Option{WithClock(newFakeClock(true))}
+
+	src := &countingIO{r: bytes.NewReader(data)}
+	r := NewLimitedReader(src, 0, opts...)
+	if n, err := r.Read(make([]byte, len(data))); n != len(data) || err != nil {
+		t.Fatalf("Read() = %d, %v, want %d, nil", n, err, len(data))
+	}
+	if src.reads != 1 {
+		t.Errorf("unlimited reader made %d reads, want 1", src.reads)
+	}
+
+	dst := &countingIO{}
+	w := NewLimitedWriter(dst, 0, opts...)
+	if n, err := w.Write(data); n != len(data) || err != nil {
+		t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(data))
+	}
+	if dst.writes != 1 {
+		t.Errorf("unlimited writer made %d writes, want 1", dst.writes)
+	}
+
+	w.SetLimit(100)
+	dst.writes = 0
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.writes != 10 {
+		t.Errorf("limited writer made %d writes, want 10", dst.writes)
+	}
+}
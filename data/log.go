@@ -0,0 +1,43 @@
+package data
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer. The Size is logged as a group with a
+// "bytes" and a "human" attribute. Log a RawSize or a HumanSize for only one of
+// them.
+func (d Size) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("bytes", int64(d)),
+		slog.String("human", d.String()),
+	)
+}
+
+// LogValue implements slog.LogValuer. The Speed is logged as a group with a
+// "bytes_per_second" and a "human" attribute. Log a RawSpeed or a HumanSpeed
+// for only one of them.
+func (s Speed) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("bytes_per_second", uint64(s)),
+		slog.String("human", s.String()),
+	)
+}
+
+// LogValue implements slog.LogValuer with the number of bytes.
+func (d RawSize) LogValue() slog.Value {
+	return slog.Int64Value(int64(d))
+}
+
+// LogValue implements slog.LogValuer with the number of bytes per second.
+func (s RawSpeed) LogValue() slog.Value {
+	return slog.Uint64Value(uint64(s))
+}
+
+// LogValue implements slog.LogValuer with the text of String.
+func (d HumanSize) LogValue() slog.Value {
+	return slog.StringValue(d.String())
+}
+
+// LogValue implements slog.LogValuer with the text of String.
+func (s HumanSpeed) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
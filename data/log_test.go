@@ -0,0 +1,49 @@
+package data
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func logLine(v any) string {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	slog.New(handler).Info("m", "v", v)
+	return strings.TrimSpace(buf.String())
+}
+
+func TestLogValue(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"size both", 1536 * Byte,
+			`{"msg":"m","v":{"bytes":1536,"human":"1.50 kiB"}}`},
+		{"size raw", RawSize(1536), `{"msg":"m","v":1536}`},
+		{"size human", HumanSize(1536), `{"msg":"m","v":"1.50 kiB"}`},
+		{"negative size raw", RawSize(-KiB), `{"msg":"m","v":-1024}`},
+		{"speed both", Speed(MiB),
+			`{"msg":"m","v":{"bytes_per_second":1048576,"human":"1.00 MiB/s"}}`},
+		{"speed raw", RawSpeed(MiB), `{"msg":"m","v":1048576}`},
+		{"speed human", HumanSpeed(MiB), `{"msg":"m","v":"1.00 MiB/s"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := logLine(tt.v)
+			if got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
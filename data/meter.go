@@ -0,0 +1,223 @@
+package data
+
+import (
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// meterBuckets is the number of buckets a Meter divides its window into. The
+// instantaneous speed is the speed over one bucket.
+const meterBuckets = 10
+
+// Meter measures the throughput of bytes passing through it. It reports the
+// instantaneous speed, a windowed average, an exponentially weighted moving
+// average (EWMA), the total, and an ETA toward an expected total.
+//
+// A Meter is safe for concurrent use. Use WithClock, WithWindow and
+// WithHalfLife to configure it.
+type Meter struct {
+	mu       sync.Mutex
+	clock    Clock
+	start    time.Time
+	total    Size
+	expected Size
+
+	// Windowed: bytes per bucket, the current bucket being tick.
+	resolution time.Duration
+	buckets    [meterBuckets]Size
+	tick       int64
+
+	// EWMA in bytes per second as of ewmaAt, with time constant tau.
+	tau    float64
+	ewma   float64
+	ewmaAt time.Time
+}
+
+// NewMeter returns a Meter that starts measuring now.
+func NewMeter(opts ...Option) *Meter {
+	o := newOptions(opts)
+	now := o.clock.Now()
+	return &Meter{
+		clock:      o.clock,
+		start:      now,
+		resolution: max(o.window/meterBuckets, 1),
+		tau:        max(o.halfLife, 1).Seconds() / math.Ln2,
+		ewmaAt:     now,
+	}
+}
+
+// Add counts n bytes.
+func (m *Meter) Add(n Size) {
+	if n <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	m.advanceLocked(now)
+	m.total = m.total.AddSaturating(n)
+	m.buckets[m.tick%meterBuckets] += n
+	m.ewma += float64(n) / m.tau
+}
+
+// Write counts len(p) bytes. It never fails, so a Meter can be used with
+// io.TeeReader or io.MultiWriter.
+func (m *Meter) Write(p []byte) (int, error) {
+	m.Add(Size(len(p)))
+	return len(p), nil
+}
+
+// Reader returns a reader that counts the bytes read from r.
+func (m *Meter) Reader(r io.Reader) io.Reader {
+	return &meterReader{r: r, m: m}
+}
+
+// Writer returns a writer that counts the bytes written to w.
+func (m *Meter) Writer(w io.Writer) io.Writer {
+	return &meterWriter{w: w, m: m}
+}
+
+// Total returns the number of bytes counted.
+func (m *Meter) Total() Size {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total
+}
+
+// Elapsed returns the time since the Meter was created.
+func (m *Meter) Elapsed() time.Duration {
+	return m.clock.Now().Sub(m.start)
+}
+
+// SetExpected sets the total expected to pass, for ETA. Zero means unknown.
+func (m *Meter) SetExpected(total Size) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expected = max(total, 0)
+}
+
+// Instant returns the speed over the last completed bucket, a tenth of the
+// window, or since the start during the first bucket.
+func (m *Meter) Instant() Speed {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	m.advanceLocked(now)
+	if m.tick == 0 {
+		return m.total.Over(now.Sub(m.start))
+	}
+	return m.buckets[(m.tick-1)%meterBuckets].Over(m.resolution)
+}
+
+// Average returns the average speed over the window, or since the start if
+// the Meter is younger than the window.
+func (m *Meter) Average() Speed {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	m.advanceLocked(now)
+
+	var sum Size
+	for _, n := range m.buckets {
+		sum = sum.AddSaturating(n)
+	}
+
+	// The oldest bucket starts meterBuckets-1 ticks before the current one.
+	from := m.start.Add(time.Duration(m.tick-meterBuckets+1) * m.resolution)
+	if from.Before(m.start) {
+		from = m.start
+	}
+	return sum.Over(now.Sub(from))
+}
+
+// EWMA returns the exponentially weighted moving average of the speed. It is
+// corrected for the start, so a steady flow is measured accurately from the
+// beginning instead of ramping up.
+func (m *Meter) EWMA() Speed {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	m.advanceLocked(now)
+
+	age := now.Sub(m.start).Seconds()
+	if age <= 0 {
+		return 0
+	}
+	return speedOf(m.ewma / -math.Expm1(-age/m.tau))
+}
+
+// ETA returns the estimated time until the expected total has passed, based on
+// the EWMA. It reports false if the expected total is unknown or nothing is
+// moving.
+func (m *Meter) ETA() (time.Duration, bool) {
+	speed := m.EWMA()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.expected == 0 {
+		return 0, false
+	}
+	remaining := m.expected - m.total
+	if remaining <= 0 {
+		return 0, true
+	}
+	if speed == 0 {
+		return 0, false
+	}
+	return speed.TimeFor(remaining), true
+}
+
+// advanceLocked decays the EWMA and clears the buckets that fell out of the
+// window up to now. The caller must hold m.mu.
+func (m *Meter) advanceLocked(now time.Time) {
+	if dt := now.Sub(m.ewmaAt); dt > 0 {
+		m.ewma *= math.Exp(-dt.Seconds() / m.tau)
+		m.ewmaAt = now
+	}
+
+	tick := int64(now.Sub(m.start) / m.resolution)
+	if tick <= m.tick {
+		return
+	}
+	for t := m.tick + 1; t <= tick && t <= m.tick+meterBuckets; t++ {
+		m.buckets[t%meterBuckets] = 0
+	}
+	m.tick = tick
+}
+
+// speedOf converts bytes per second to the nearest Speed, saturating at the
+// largest Speed.
+func speedOf(bytesPerSecond float64) Speed {
+	switch {
+	case !(bytesPerSecond > 0):
+		return 0
+	case bytesPerSecond >= math.MaxUint64:
+		return math.MaxUint64
+	}
+	return Speed(math.Round(bytesPerSecond))
+}
+
+type meterReader struct {
+	r io.Reader
+	m *Meter
+}
+
+func (r *meterReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.m.Add(Size(n))
+	return n, err
+}
+
+type meterWriter struct {
+	w io.Writer
+	m *Meter
+}
+
+func (w *meterWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.m.Add(Size(n))
+	return n, err
+}
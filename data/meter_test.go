@@ -0,0 +1,144 @@
+package data
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// steady adds n bytes every step for dur.
+func steady(m *Meter, clock *fakeClock, n Size, step, dur time.Duration) {
+	for elapsed := time.Duration(0); elapsed < dur; elapsed += step {
+		clock.Advance(step)
+		m.Add(n)
+	}
+}
+
+// near reports whether got is within 5% of want. Samples that land exactly on
+// the edge of a window make the averages a little imprecise.
+func near(got, want Speed) bool {
+	return got >= want-want/20 && got <= want+want/20
+}
+
+func TestMeter_Speeds(t *testing.T) {
+	clock := newFakeClock(false)
+	m := NewMeter(WithClock(clock))
+	steady(m, clock, 100, 100*time.Millisecond, 20*time.Second)
+
+	if got := m.Total(); got != 20*KB {
+		t.Fatalf("Total() = %d, want %d", got, 20*KB)
+	}
+	if got := m.Instant(); got != Speed(KB) {
+		t.Errorf("Instant() = %v, want %v", got, Speed(KB))
+	}
+	if got := m.Average(); !near(got, Speed(KB)) {
+		t.Errorf("Average() = %v, want about %v", got, Speed(KB))
+	}
+	if got := m.EWMA(); !near(got, Speed(KB)) {
+		t.Errorf("EWMA() = %v, want about %v", got, Speed(KB))
+	}
+
+	// Speed up: the instant speed follows at once, the EWMA lags.
+	steady(m, clock, 300, 100*time.Millisecond, 2*time.Second)
+	if got := m.Instant(); got != Speed(3*KB) {
+		t.Errorf("Instant() = %v, want %v", got, Speed(3*KB))
+	}
+	if got := m.Average(); !near(got, Speed(1400)) {
+		t.Errorf("Average() = %v, want about %v", got, Speed(1400))
+	}
+	if got := m.EWMA(); got <= Speed(KB) || got >= Speed(2*KB) {
+		t.Errorf("EWMA() = %v, want between 1 and 2 kB/s", got)
+	}
+
+	// Go idle.
+	clock.Advance(time.Minute)
+	if got := m.Instant(); got != 0 {
+		t.Errorf("idle Instant() = %v, want 0", got)
+	}
+	if got := m.Average(); got != 0 {
+		t.Errorf("idle Average() = %v, want 0", got)
+	}
+	if got := m.EWMA(); got >= 10 {
+		t.Errorf("idle EWMA() = %v, want almost 0", got)
+	}
+}
+
+func TestMeter_Start(t *testing.T) {
+	clock := newFakeClock(false)
+	m := NewMeter(WithClock(clock), WithWindow(time.Minute))
+	if m.Instant() != 0 || m.Average() != 0 || m.EWMA() != 0 {
+		t.Fatal("new meter is not idle")
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	m.Add(500)
+	for name, got := range map[string]Speed{
+		"Instant": m.Instant(),
+		"Average": m.Average(),
+		"EWMA":    m.EWMA(),
+	} {
+		if !near(got, Speed(KB)) {
+			t.Errorf("%s() = %v, want about %v", name, got, Speed(KB))
+		}
+	}
+}
+
+func TestMeter_ETA(t *testing.T) {
+	clock := newFakeClock(false)
+	m := NewMeter(WithClock(clock))
+	if _, ok := m.ETA(); ok {
+		t.Fatal("ETA without an expected total")
+	}
+
+	m.SetExpected(10 * KB)
+	if _, ok := m.ETA(); ok {
+		t.Fatal("ETA without any progress")
+	}
+
+	steady(m, clock, 100, 100*time.Millisecond, 5*time.Second)
+	eta, ok := m.ETA()
+	if !ok || eta < 4900*time.Millisecond || eta > 5100*time.Millisecond {
+		t.Fatalf("ETA() = %v, %v, want about 5s", eta, ok)
+	}
+
+	m.Add(5 * KB)
+	if eta, ok := m.ETA(); !ok || eta != 0 {
+		t.Fatalf("ETA() = %v, %v, want 0, true when done", eta, ok)
+	}
+}
+
+func TestMeter_Concurrent(t *testing.T) {
+	m := NewMeter()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 1000 {
+				m.Add(1)
+				m.EWMA()
+			}
+		})
+	}
+	wg.Wait()
+	if got := m.Total(); got != 8000 {
+		t.Fatalf("Total() = %d, want 8000", got)
+	}
+}
+
+func TestMeter_ReaderWriter(t *testing.T) {
+	m := NewMeter()
+	var buf bytes.Buffer
+
+	r := m.Reader(strings.NewReader("real"))
+	if _, err := io.Copy(m.Writer(&buf), r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := io.Copy(io.Discard, io.TeeReader(&buf, m)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.Total(); got != 12 {
+		t.Fatalf("Total() = %d, want 12", got)
+	}
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"context"
+	"time"
+)
+
+// Option configures a Limiter, Bandwidth, LimitedReader, LimitedWriter or
+// Meter. Each option documents which of them it applies to; the others ignore
+// it.
+type Option func(*options)
+
+type options struct {
+	burst    Size
+	clock    Clock
+	ctx      context.Context
+	window   time.Duration
+	halfLife time.Duration
+}
+
+// WithBurst sets the largest number of bytes that may pass a limiter at once.
+// A burst of zero or less uses the default of one second's worth of data.
+func WithBurst(burst Size) Option {
+	return func(o *options) { o.burst = burst }
+}
+
+// WithClock sets the Clock used to measure time, instead of the system clock.
+// It applies to everything that takes options.
+func WithClock(c Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+// WithContext sets the context that cancels the waits of a LimitedReader or
+// LimitedWriter. The Read or Write in progress returns the context's error.
+// Limiter ignores it, since WaitN takes its own context.
+func WithContext(ctx context.Context) Option {
+	return func(o *options) { o.ctx = ctx }
+}
+
+// WithWindow sets the period over which a Meter averages its windowed speed.
+// The default is 10 seconds.
+func WithWindow(window time.Duration) Option {
+	return func(o *options) { o.window = window }
+}
+
+// WithHalfLife sets how quickly the moving average of a Meter forgets: a
+// sample counts half as much after each half-life. The default is 5 seconds.
+func WithHalfLife(halfLife time.Duration) Option {
+	return func(o *options) { o.halfLife = halfLife }
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		clock:    systemClock{},
+		ctx:      context.Background(),
+		window:   10 * time.Second,
+		halfLife: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,117 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Calendar periods for formatting and parsing speeds. A month and a year are
+// the averages of the Gregorian calendar.
+const (
+	Day   = 24 * time.Hour
+	Week  = 7 * Day
+	Month = 2629746 * time.Second
+	Year  = 31556952 * time.Second
+)
+
+// periods are the periods with a name, from the shortest, and the name used
+// when formatting a speed per each.
+var periods = []struct {
+	d    time.Duration
+	name string
+}{
+	{time.Nanosecond, "ns"},
+	{time.Microsecond, "µs"},
+	{time.Millisecond, "ms"},
+	{time.Second, "s"},
+	{time.Minute, "min"},
+	{time.Hour, "h"},
+	{Day, "day"},
+	{Week, "week"},
+	{Month, "month"},
+	{Year, "year"},
+}
+
+// autoPeriods are the periods Per may choose from automatically.
+var autoPeriods = []time.Duration{
+	time.Second, time.Minute, time.Hour, Day, Month, Year,
+}
+
+// SpeedPer formats a speed as an amount per period, such as "3.20 TB/day" or
+// "12.00 GiB/month". It is returned by Speed.Per and PreciseSpeed.Per.
+type SpeedPer struct {
+	perSecond *big.Rat
+	period    time.Duration
+}
+
+// Per returns s for formatting per period instead of per second. A period of
+// zero chooses one automatically, see PreciseSpeed.Per.
+func (s Speed) Per(period time.Duration) SpeedPer {
+	return PreciseSpeedOf(s).Per(period)
+}
+
+// Per returns p for formatting per period instead of per second. Periods
+// with a name are written as "s", "min", "h", "day", "week", "month", "year"
+// and so on; others are written like time.Duration, such as "/10s".
+//
+// A period of zero or less chooses the shortest of a second, minute, hour,
+// day, month and year over which at least a byte moves, so very slow speeds
+// read as "5.00 B/min" rather than "0.08 B/s".
+func (p PreciseSpeed) Per(period time.Duration) SpeedPer {
+	if period <= 0 {
+		period = autoPeriod(p.rat())
+	}
+	return SpeedPer{perSecond: p.rat(), period: period}
+}
+
+// Period returns the period of the SpeedPer.
+func (s SpeedPer) Period() time.Duration {
+	return s.period
+}
+
+// Amount returns the exact number of bytes moved per period.
+func (s SpeedPer) Amount() *big.Rat {
+	return amountPer(s.perSecond, s.period)
+}
+
+// Format implements fmt.Formatter with the verbs of PreciseSpeed.Format.
+func (s SpeedPer) Format(f fmt.State, verb rune) {
+	formatRatPer(f, verb, s.Amount(), "/"+periodName(s.period))
+}
+
+// String returns the amount per period in binary byte units with two decimal
+// places, such as "1.50 GiB/day".
+func (s SpeedPer) String() string {
+	return formatRat(s.Amount(), FormatBinaryByte, 2) + "/" +
+		periodName(s.period)
+}
+
+func autoPeriod(perSecond *big.Rat) time.Duration {
+	if perSecond.Sign() == 0 {
+		return time.Second
+	}
+	for _, d := range autoPeriods {
+		if amountPer(perSecond, d).Cmp(big.NewRat(1, 1)) >= 0 {
+			return d
+		}
+	}
+	return autoPeriods[len(autoPeriods)-1]
+}
+
+func amountPer(perSecond *big.Rat, period time.Duration) *big.Rat {
+	if perSecond == nil {
+		return new(big.Rat)
+	}
+	r := new(big.Rat).Mul(perSecond, big.NewRat(int64(period), 1))
+	return r.Quo(r, big.NewRat(int64(time.Second), 1))
+}
+
+func periodName(d time.Duration) string {
+	for _, p := range periods {
+		if p.d == d {
+			return p.name
+		}
+	}
+	return d.String()
+}
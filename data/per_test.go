@@ -0,0 +1,76 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestSpeedPer_Format(t *testing.T) {
+	slow, _ := ParsePreciseSpeed("5 B/min")
+	tiny, _ := ParsePreciseSpeed("1 B/week")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"day", fmt.Sprintf("%.1M", Speed(37037037).Per(Day)), "3.2 TB/day"},
+		{"hour", fmt.Sprintf("%.0M", Speed(150*KB).Per(time.Hour)), "540 MB/h"},
+		{"month", fmt.Sprintf("%.0B", Speed(4903).Per(Month)), "12 GiB/month"},
+		{"bits", fmt.Sprintf("%m", Speed(KB).Per(time.Minute)), "480.00 Kb/min"},
+		{"string", Speed(KiB).Per(Week).String(), "590.63 MiB/week"},
+		{"second", Speed(MiB).Per(time.Second).String(), "1.00 MiB/s"},
+		{"milliseconds", Speed(MB).Per(time.Millisecond).String(), "1000.00 B/ms"},
+		{"duration", Speed(100).Per(10 * time.Second).String(), "1000.00 B/10s"},
+		{"exact", fmt.Sprintf("%d", slow.Per(time.Hour)), "300/h"},
+		{"auto fast", Speed(MiB).Per(0).String(), "1.00 MiB/s"},
+		{"auto slow", slow.Per(0).String(), "5.00 B/min"},
+		{"auto tiny", tiny.Per(0).String(), "4.35 B/month"},
+		{"auto zero", Speed(0).Per(0).String(), "0.00 B/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeedPer_RoundTrip(t *testing.T) {
+	speeds := []string{"1 B/min", "3 GB/day", "1 kB/month", "12 GiB/year"}
+	periods := []time.Duration{0, time.Minute, Day, Month, 10 * time.Second}
+
+	for _, in := range speeds {
+		want, err := ParsePreciseSpeed(in)
+		if err != nil {
+			t.Fatalf("ParsePreciseSpeed(%q): %v", in, err)
+		}
+		for _, period := range periods {
+			formatted := fmt.Sprintf("%.30B", want.Per(period))
+			got, err := ParsePreciseSpeed(formatted)
+			if err != nil {
+				t.Fatalf("ParsePreciseSpeed(%q): %v", formatted, err)
+			}
+			// 30 decimal places are close enough for the average month.
+			diff := got.Rat()
+			diff.Sub(diff, want.Rat())
+			if f, _ := diff.Float64(); f > 1e-15 || f < -1e-15 {
+				t.Errorf("%q formatted as %q parses to %v", in, formatted, got)
+			}
+		}
+	}
+}
+
+func TestSpeedPer_Amount(t *testing.T) {
+	p := Speed(KB).Per(Day)
+	if p.Period() != Day {
+		t.Fatalf("Period() = %v, want %v", p.Period(), Day)
+	}
+	if got := p.Amount(); got.Cmp(big.NewRat(86400000, 1)) != 0 {
+		t.Fatalf("Amount() = %v, want 86400000", got)
+	}
+}
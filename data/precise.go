@@ -0,0 +1,140 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// PreciseSpeed is an exact, rational data transfer speed in bytes per second.
+//
+// Speed stores whole bytes per second, so 1 byte over 3 seconds becomes 0 B/s.
+// A PreciseSpeed keeps the fraction, which matters for slow links that move a
+// few bytes per minute, and it never overflows. Speed returns the rounded view.
+//
+// The zero value is a speed of zero. A PreciseSpeed is immutable.
+type PreciseSpeed struct {
+	r *big.Rat
+}
+
+// NewPreciseSpeed creates an exact speed from given amount and time.
+func NewPreciseSpeed(amount Size, dur time.Duration) (PreciseSpeed, error) {
+	return NewPreciseSpeedBig(NewBigSize(amount), dur)
+}
+
+// NewPreciseSpeedBig creates an exact speed from an amount of any size and a
+// time. Like NewSpeedE, a zero duration gives a zero speed and a negative
+// amount or duration is an error.
+func NewPreciseSpeedBig(
+	amount BigSize, dur time.Duration,
+) (PreciseSpeed, error) {
+	if dur < 0 {
+		return PreciseSpeed{}, fmt.Errorf("negative duration: %d", dur)
+	}
+
+	if dur == 0 {
+		return PreciseSpeed{}, nil
+	}
+
+	if amount.Sign() < 0 {
+		return PreciseSpeed{}, fmt.Errorf("negative amount: %d", amount)
+	}
+
+	r := new(big.Rat).SetFrac(amount.Int(), big.NewInt(int64(dur)))
+	r.Mul(r, big.NewRat(int64(time.Second), 1))
+	return PreciseSpeed{r}, nil
+}
+
+// ParsePreciseSpeed parses a dataspeed, with the syntax of ParseSpeed, to an
+// exact PreciseSpeed, so "5 B/min" keeps its fraction of a byte per second.
+func ParsePreciseSpeed(s string) (PreciseSpeed, error) {
+	perSecond, err := parsePerTime(s)
+	if err != nil {
+		return PreciseSpeed{}, err
+	}
+	if perSecond.Sign() < 0 {
+		return PreciseSpeed{}, fmt.Errorf("negative dataspeed: %q", s)
+	}
+	return PreciseSpeed{perSecond}, nil
+}
+
+// PreciseSpeedOf returns the Speed as a PreciseSpeed.
+func PreciseSpeedOf(s Speed) PreciseSpeed {
+	return PreciseSpeed{new(big.Rat).SetUint64(uint64(s))}
+}
+
+// rat returns the value as a big.Rat that must not be modified.
+func (p PreciseSpeed) rat() *big.Rat {
+	if p.r == nil {
+		return new(big.Rat)
+	}
+	return p.r
+}
+
+// Rat returns the speed in bytes per second as a new big.Rat.
+func (p PreciseSpeed) Rat() *big.Rat {
+	return new(big.Rat).Set(p.rat())
+}
+
+// BytesPerSecond returns the speed in bytes per second as the nearest float64.
+func (p PreciseSpeed) BytesPerSecond() float64 {
+	f, _ := p.rat().Float64()
+	return f
+}
+
+// Speed returns the speed truncated to whole bytes per second, the same value
+// NewSpeedE computes. It returns an error matching ErrOverflow if the speed
+// does not fit in a Speed.
+func (p PreciseSpeed) Speed() (Speed, error) {
+	n := new(big.Int).Quo(p.rat().Num(), p.rat().Denom())
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("%w: speed overflows uint64", ErrOverflow)
+	}
+	return Speed(n.Uint64()), nil
+}
+
+// Cmp compares p and o and returns -1, 0 or +1.
+func (p PreciseSpeed) Cmp(o PreciseSpeed) int {
+	return p.rat().Cmp(o.rat())
+}
+
+// Format implements fmt.Formatter with the same verbs as Speed.Format. Unlike
+// Speed, raw bytes and bits are printed with two decimal places by default, so
+// that slow speeds such as "0.33 B/s" stay visible. %d prints the exact
+// fraction of bytes per second, such as "1/3".
+func (p PreciseSpeed) Format(f fmt.State, verb rune) {
+	formatRatPer(f, verb, p.rat(), "/s")
+}
+
+// String returns the default string representation of the PreciseSpeed, in
+// binary byte units per second with two decimal places.
+func (p PreciseSpeed) String() string {
+	return formatRat(p.rat(), FormatBinaryByte, 2) + "/s"
+}
+
+// formatRatPer formats an exact amount of bytes per period for a Format
+// method, with suffix naming the period.
+func formatRatPer(f fmt.State, verb rune, r *big.Rat, suffix string) {
+	var text string
+	if verb == 'd' {
+		text = r.RatString() + suffix
+	} else {
+		family, ok := formatFamily(verb)
+		if !ok {
+			family = FormatBinaryByte
+		}
+		precision, fixed := f.Precision()
+		if !fixed {
+			precision = 2
+		}
+		text = formatRat(r, family, precision) + suffix
+	}
+	writeFormatted(f, verb, text, r.Sign() >= 0)
+}
+
+// formatRat formats an exact amount of bytes in the best unit of family.
+func formatRat(r *big.Rat, family FormatUnit, prec int) string {
+	u := bestBigUnit(r, family)
+	value := new(big.Rat).Quo(r, u.bytes)
+	return value.FloatString(prec) + " " + u.name
+}
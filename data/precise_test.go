@@ -0,0 +1,140 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestNewPreciseSpeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Size
+		dur     time.Duration
+		want    *big.Rat
+		wantErr bool
+	}{
+		{"one byte in three seconds", 1, 3 * time.Second, big.NewRat(1, 3), false},
+		{"few bytes per minute", 5, time.Minute, big.NewRat(1, 12), false},
+		{"whole", 100 * MB, 2 * time.Second, big.NewRat(int64(50*MB), 1), false},
+		{"huge amount tiny duration", 9 * EB, time.Nanosecond,
+			new(big.Rat).Mul(big.NewRat(int64(9*EB), 1), big.NewRat(1e9, 1)), false},
+		{"tiny amount huge duration", 1, math.MaxInt64,
+			big.NewRat(1e9, math.MaxInt64), false},
+		{"zero duration", MB, 0, new(big.Rat), false},
+		{"negative duration", MB, -1, nil, true},
+		{"negative amount", -1, time.Second, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPreciseSpeed(tt.amount, tt.dur)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Rat().Cmp(tt.want) != 0 {
+				t.Fatalf("got %s, want %s", got.Rat(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPreciseSpeedBig(t *testing.T) {
+	got, err := NewPreciseSpeedBig(ZB, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := new(big.Rat).SetFrac(ZB.Int(), big.NewInt(3600))
+	if got.Rat().Cmp(want) != 0 {
+		t.Fatalf("got %s, want %s", got.Rat(), want)
+	}
+}
+
+func TestPreciseSpeed_Speed(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Size
+		dur     time.Duration
+		wantErr bool
+	}{
+		{"fraction truncates", 1, 3 * time.Second, false},
+		{"exact", 100 * MB, 2 * time.Second, false},
+		{"rounds like NewSpeedE", 1000, 3 * time.Second, false},
+		{"large amount", 9 * EB, time.Hour, false},
+		{"overflow", 9 * EB, time.Nanosecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPreciseSpeed(tt.amount, tt.dur)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := p.Speed()
+			want, wantErr := NewSpeedE(tt.amount, tt.dur)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOverflow) || wantErr == nil {
+					t.Fatalf("got %v and %v, want overflow errors", err, wantErr)
+				}
+				return
+			}
+			if err != nil || wantErr != nil {
+				t.Fatalf("unexpected errors: %v, %v", err, wantErr)
+			}
+			if got != want {
+				t.Fatalf("got %d, NewSpeedE gives %d", got, want)
+			}
+		})
+	}
+}
+
+func TestPreciseSpeed_Format(t *testing.T) {
+	third, _ := NewPreciseSpeed(1, 3*time.Second)
+	slow, _ := NewPreciseSpeed(5, time.Minute)
+	fast, _ := NewPreciseSpeed(3*MiB, 2*time.Second)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"zero", PreciseSpeed{}.String(), "0.00 B/s"},
+		{"third", third.String(), "0.33 B/s"},
+		{"slow", fmt.Sprintf("%.3B", slow), "0.083 B/s"},
+		{"bits", fmt.Sprintf("%b", third), "2.67 b/s"},
+		{"binary", fmt.Sprintf("%B", fast), "1.50 MiB/s"},
+		{"metric", fmt.Sprintf("%.1M", fast), "1.6 MB/s"},
+		{"exact fraction", fmt.Sprintf("%d", third), "1/3/s"},
+		{"from speed", PreciseSpeedOf(Speed(KiB)).String(), "1.00 kiB/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreciseSpeed_Cmp(t *testing.T) {
+	third, _ := NewPreciseSpeed(1, 3*time.Second)
+	half, _ := NewPreciseSpeed(1, 2*time.Second)
+
+	if third.Cmp(half) != -1 || half.Cmp(third) != 1 || third.Cmp(third) != 0 {
+		t.Fatal("unexpected ordering")
+	}
+	if got := third.BytesPerSecond(); math.Abs(got-1.0/3) > 1e-15 {
+		t.Fatalf("BytesPerSecond() = %v", got)
+	}
+}
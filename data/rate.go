@@ -0,0 +1,124 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// Rate represents a signed rate of change of data in bytes per second, such
+// as a queue draining at -20 MiB/s.
+//
+// Unlike Speed, which is an unsigned throughput, a Rate has the same range and
+// signedness as Size.
+type Rate int64
+
+// NewRate creates a rate from given amount and time.
+// It panics on invalid input.
+func NewRate(amount Size, dur time.Duration) Rate {
+	rate, err := NewRateE(amount, dur)
+	if err != nil {
+		panic(err)
+	}
+	return rate
+}
+
+// NewRateE creates a rate from given amount and time, truncating toward zero.
+// It returns an error instead of panicking.
+func NewRateE(amount Size, dur time.Duration) (Rate, error) {
+	if dur < 0 {
+		return 0, fmt.Errorf("negative duration: %d", dur)
+	}
+
+	if dur == 0 {
+		return 0, nil
+	}
+
+	perSecond := big.NewInt(int64(amount))
+	perSecond.Mul(perSecond, big.NewInt(int64(time.Second)))
+	perSecond.Quo(perSecond, big.NewInt(int64(dur)))
+	if !perSecond.IsInt64() {
+		return 0, fmt.Errorf("%w: rate overflows int64", ErrOverflow)
+	}
+	return Rate(perSecond.Int64()), nil
+}
+
+// ParseRate parses a signed dataspeed, such as "-5 MB/s", to Rate, with the
+// same syntax as ParseSpeed.
+func ParseRate(s string) (Rate, error) {
+	perSecond, err := parsePerTime(s)
+	if err != nil {
+		return 0, err
+	}
+
+	n := roundRat(perSecond)
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("%w: rate overflows int64: %q", ErrOverflow, s)
+	}
+	return Rate(n.Int64()), nil
+}
+
+// Rate converts the Speed to a Rate. It returns an error matching ErrOverflow
+// if the Speed is larger than the largest Rate.
+func (s Speed) Rate() (Rate, error) {
+	if s > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: speed %d does not fit in a Rate",
+			ErrOverflow, uint64(s))
+	}
+	return Rate(s), nil
+}
+
+// Speed converts the Rate to a Speed. It returns an error if the Rate is
+// negative.
+func (r Rate) Speed() (Speed, error) {
+	if r < 0 {
+		return 0, fmt.Errorf("negative rate: %d", int64(r))
+	}
+	return Speed(r), nil
+}
+
+// Abs returns the magnitude of the Rate as a Speed. Unlike negating the Rate,
+// it cannot overflow.
+func (r Rate) Abs() Speed {
+	if r < 0 {
+		return Speed(-uint64(r))
+	}
+	return Speed(r)
+}
+
+// Size returns the rate as a Size (bytes per second).
+func (r Rate) Size() Size {
+	return Size(r)
+}
+
+// FormatUnitString formats the Rate using the specified unit and precision,
+// like Speed.FormatUnitString.
+func (r Rate) FormatUnitString(unit string, precision ...int) string {
+	return r.Size().FormatUnitString(unit, precision...) + "/s"
+}
+
+// FormatIn formats the Rate in the given unit per second, like
+// Speed.FormatIn.
+func (r Rate) FormatIn(u Unit, precision ...int) string {
+	return r.Size().FormatIn(u, precision...) + "/s"
+}
+
+// Format implements fmt.Formatter with the same verbs and flags as
+// Speed.Format. The + flag forces a sign on non-negative rates: %+.1B prints
+// "+20.0 MiB/s".
+func (r Rate) Format(f fmt.State, verb rune) {
+	writeFormatted(f, verb, r.Size().formatVerb(f, verb)+"/s", r >= 0)
+}
+
+// String returns the default string representation of the Rate, such as
+// "-20.00 MiB/s".
+func (r Rate) String() string {
+	return r.Size().String() + "/s"
+}
+
+// Exact returns a lossless string representation of the Rate, so
+// ParseRate(r.Exact()) always returns r.
+func (r Rate) Exact() string {
+	return r.Size().Exact() + "/s"
+}
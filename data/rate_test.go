@@ -0,0 +1,141 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewRateE(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Size
+		dur     time.Duration
+		want    Rate
+		wantErr bool
+	}{
+		{"positive", 100 * MB, 2 * time.Second, Rate(50 * MB), false},
+		{"negative", -40 * MiB, 2 * time.Second, Rate(-20 * MiB), false},
+		{"truncates toward zero", -1, 3 * time.Second, 0, false},
+		{"zero duration", MB, 0, 0, false},
+		{"large amount", 9 * EB, time.Hour, Rate(9 * EB / 3600), false},
+		{"negative duration", MB, -time.Second, 0, true},
+		{"overflow", 9 * EB, time.Nanosecond, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRateE(tt.amount, tt.dur)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Rate
+		wantErr bool
+	}{
+		{"negative", "-5 MB/s", Rate(-5 * MB), false},
+		{"positive", "20MiB/s", Rate(20 * MiB), false},
+		{"explicit plus", "+1 KiB/s", Rate(KiB), false},
+		{"negative per millisecond", "-1B/ms", -1000, false},
+		{"String output", "-1.50 kiB/s", -1536, false},
+		{"missing separator", "-5MB", 0, true},
+		{"sign only", "+ per hour", 0, true},
+		{"bad size", "-XB/s", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseRate(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate_Conversions(t *testing.T) {
+	if r, err := Speed(MiB).Rate(); err != nil || r != Rate(MiB) {
+		t.Fatalf("Speed.Rate() = %v, %v", r, err)
+	}
+	if _, err := Speed(math.MaxUint64).Rate(); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got %v, want ErrOverflow", err)
+	}
+
+	if s, err := Rate(MiB).Speed(); err != nil || s != Speed(MiB) {
+		t.Fatalf("Rate.Speed() = %v, %v", s, err)
+	}
+	if _, err := Rate(-1).Speed(); err == nil {
+		t.Fatal("expected error for negative rate")
+	}
+
+	if got := Rate(-20 * MiB).Abs(); got != Speed(20*MiB) {
+		t.Fatalf("Abs() = %v", got)
+	}
+	if got := Rate(math.MinInt64).Abs(); got != 1<<63 {
+		t.Fatalf("Abs() = %d, want %d", got, uint64(1<<63))
+	}
+}
+
+func TestRate_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"string", Rate(-20 * MiB).String(), "-20.00 MiB/s"},
+		{"positive string", Rate(1536).String(), "1.50 kiB/s"},
+		{"binary", fmt.Sprintf("%.1B", Rate(-20*MiB)), "-20.0 MiB/s"},
+		{"forced sign", fmt.Sprintf("%+.1B", Rate(20*MiB)), "+20.0 MiB/s"},
+		{"metric bits", fmt.Sprintf("%m", Rate(-MB)), "-8.00 Mb/s"},
+		{"unit string", Rate(-1536).FormatUnitString("KiB", 1), "-1.5 KiB/s"},
+		{"unit", Rate(-MB).FormatIn(UnitKB), "-1000 kB/s"},
+		{"exact", Rate(-MiB - 1).Exact(), "-1.00000095367431640625 MiB/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate_RoundTrip(t *testing.T) {
+	for _, r := range []Rate{0, 1, -1, -1536, Rate(GiB) + 1, -Rate(EiB)} {
+		got, err := ParseRate(r.Exact())
+		if err != nil {
+			t.Fatalf("ParseRate(%q) unexpected error: %v", r.Exact(), err)
+		}
+		if got != r {
+			t.Fatalf("ParseRate(%q) = %d, want %d", r.Exact(), got, r)
+		}
+	}
+}
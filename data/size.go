@@ -75,51 +75,105 @@ const (
 	Gib Size = GiB / 8
 	Tib Size = TiB / 8
 	Pib Size = PiB / 8
+	Eib Size = EiB / 8
 )
 
 // revive:enable exported
 
-// ParseSize parses a datasize to Size
+// ParseSize parses a datasize to Size.
+//
+// The numeric part may be an integer ("42 KiB"), a decimal fraction
+// ("1.5 GiB") or use scientific notation ("2e3 kB"). The value is multiplied
+// by the unit exactly and then rounded to the nearest byte, with halves rounded
+// away from zero, so "0.5 B" is 1 byte and "1.4 B" is 1 byte.
+//
+// Like time.ParseDuration, the input may be a sequence of components that are
+// summed, such as "1GiB512MiB", "1 GiB 512 MiB" or "3TB 200GB". A leading sign
+// applies to the whole sum. Only the last component may omit its unit, in which
+// case it is read as bytes.
 func ParseSize(s string) (Size, error) {
-	trimmed := strings.TrimSpace(s)
-	numEnd := strings.LastIndexFunc(trimmed, unicode.IsDigit) + 1
-	if numEnd <= 0 {
-		return 0, fmt.Errorf("invalid size format: %q", s)
-	}
-	num, inputUnit := trimmed[:numEnd], trimmed[numEnd:]
-	size, err := strconv.ParseInt(num, 10, 64)
+	total, err := parseBytes(s, unitBytes)
 	if err != nil {
 		return 0, err
 	}
 
-	unit := strings.TrimSpace(inputUnit)
-	if unit == "" {
-		unit = "B" // default unit is byte
+	bytes := roundRat(total)
+	if !bytes.IsInt64() {
+		return 0, fmt.Errorf("%w: size overflows int64: %q", ErrOverflow, s)
 	}
 
-	// we want convert mib or tib but not weird mIb
-	if all(unit, unicode.IsLower) {
-		unit = strings.Map(func(r rune) rune {
-			if r == 'i' {
-				return r
-			}
-			return unicode.ToUpper(r)
-		}, unit)
+	return Size(bytes.Int64()), nil
+}
+
+// unitBytes returns the exact number of bytes in the named unit.
+func unitBytes(name string) (*big.Rat, bool) {
+	u, err := ParseUnit(name)
+	if err != nil {
+		return nil, false
 	}
+	return u.bytes(), true
+}
 
-	mul, ok := UnitTable[unit]
-	if !ok {
-		return 0, fmt.Errorf("invalid input unit: %q", inputUnit)
+// parseBytes parses a, possibly compound, datasize into an exact number of
+// bytes, looking up the number of bytes in each unit with lookup.
+func parseBytes(
+	s string, lookup func(unit string) (*big.Rat, bool),
+) (*big.Rat, error) {
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return nil, fmt.Errorf("invalid size format: %q", s)
 	}
 
-	if size > 0 && size > math.MaxInt64/int64(mul) {
-		return 0, fmt.Errorf("size overflows int64: %q", s)
+	neg := false
+	if rest[0] == '-' || rest[0] == '+' {
+		neg = rest[0] == '-'
+		rest = rest[1:]
+		if rest == "" {
+			return nil, fmt.Errorf("invalid size format: %q", s)
+		}
 	}
-	if size < 0 && size < math.MinInt64/int64(mul) {
-		return 0, fmt.Errorf("size overflows int64: %q", s)
+
+	total := new(big.Rat)
+	for rest != "" {
+		numEnd := scanNumber(rest)
+		if numEnd == 0 {
+			return nil, fmt.Errorf("invalid size format: %q", s)
+		}
+		num := rest[:numEnd]
+		rest = strings.TrimLeftFunc(rest[numEnd:], unicode.IsSpace)
+
+		unitEnd := strings.IndexFunc(rest, func(r rune) bool {
+			return !unicode.IsLetter(r)
+		})
+		if unitEnd < 0 {
+			unitEnd = len(rest)
+		}
+		unit := rest[:unitEnd]
+		rest = strings.TrimLeftFunc(rest[unitEnd:], unicode.IsSpace)
+		if unit == "" && rest != "" {
+			return nil, fmt.Errorf("missing unit in size: %q", s)
+		}
+
+		value, err := parseDecimal(num)
+		if err != nil {
+			return nil, err
+		}
+
+		mul := big.NewRat(1, 1) // default unit is byte
+		if unit != "" {
+			var ok bool
+			if mul, ok = lookup(unit); !ok {
+				return nil, fmt.Errorf("invalid input unit: %q", unit)
+			}
+		}
+
+		total.Add(total, value.Mul(value, mul))
 	}
 
-	return Size(size) * mul, nil
+	if neg {
+		total.Neg(total)
+	}
+	return total, nil
 }
 
 func all(s string, f func(rune) bool) bool {
@@ -154,8 +208,15 @@ var UnitTable = map[string]Size{
 	"kiB": KiB, "KiB": KiB, "MiB": MiB, "GiB": GiB, "TiB": TiB, "PiB": PiB, "EiB": EiB,
 	"kb": Kb, "Kb": Kb, "Mb": Mb, "Gb": Gb, "Tb": Tb, "Pb": Pb, "Eb": Eb,
 	"kib": Kib, "Kib": Kib, "Mib": Mib, "Gib": Gib, "Tib": Tib, "Pib": Pib,
+	"Eib": Eib,
 }
 
+// ExactPrecision can be passed as the precision to FormatUnitString to print
+// the value with as many decimal places as needed to represent it exactly.
+// Every supported unit is a product of powers of two and five, so the result
+// always terminates and parses back to the same Size.
+const ExactPrecision = -1
+
 // FormatUnitString formats the Size using the specified unit and precision.
 //
 // Supported units include:
@@ -166,40 +227,73 @@ var UnitTable = map[string]Size{
 //   - kib, Kib, Mib, Gib, Tib, Pib, Eib
 //
 // A precision of zero prints an integer value. For bits and bytes, precision
-// greater than zero appends a fractional part of zeros.
+// greater than zero appends a fractional part of zeros. ExactPrecision prints
+// the shortest exact decimal value.
+//
+// The lowercase bit aliases kb and kib are written as Kb and Kib, because
+// ParseSize reads all-lowercase units as bytes.
+//
+// FormatUnitString is kept for compatibility; FormatIn takes a typed Unit and
+// cannot panic on a misspelled unit.
 func (d Size) FormatUnitString(unit string, precision ...int) string {
+	u, name := formatUnitName(unit)
+	return d.formatUnit(u, name, islices.OptionalValue(0, precision))
+}
+
+// formatUnitName looks up the unit of FormatUnitString and returns it with the
+// name to print, which is normalized for bit units. It panics on an unknown
+// unit.
+func formatUnitName(unit string) (Unit, string) {
+	u, ok := unitNames[unit]
+	if !ok {
+		panic("illegal diskspace unit")
+	}
+	if u.IsBit() {
+		return u, u.String()
+	}
+	return u, unit
+}
+
+// FormatIn formats the Size in the given unit with the given precision, like
+// FormatUnitString.
+func (d Size) FormatIn(u Unit, precision ...int) string {
+	return d.formatUnit(u, u.String(), islices.OptionalValue(0, precision))
+}
+
+// formatUnit formats the Size in u, writing name as the unit.
+func (d Size) formatUnit(u Unit, name string, prec int) string {
 	if d == 0 {
-		return "0 " + unit
+		return "0 " + name
 	}
 
-	prec := islices.OptionalValue(0, precision)
+	if prec == ExactPrecision {
+		if u == UnitByte || u == UnitBit {
+			prec = 0
+		} else {
+			return d.exactString(u, name)
+		}
+	}
 
 	// Handle bytes.
-	if unit == "B" {
+	if u == UnitByte {
 		if prec == 0 {
-			return fmt.Sprintf("%d %s", int64(d), unit)
+			return fmt.Sprintf("%d %s", int64(d), name)
 		}
-		return fmt.Sprintf("%d.%0*d %s", int64(d), prec, 0, unit)
+		return fmt.Sprintf("%d.%0*d %s", int64(d), prec, 0, name)
 	}
 
 	// Handle bits.
-	if unit == "b" {
+	if u == UnitBit {
 		bits := big.NewInt(int64(d))
 		bits.Mul(bits, big.NewInt(8))
 
 		if prec == 0 {
-			return fmt.Sprintf("%s %s", bits, unit)
+			return fmt.Sprintf("%s %s", bits, name)
 		}
-		return fmt.Sprintf("%s.%0*d %s", bits, prec, 0, unit)
-	}
-
-	u, ok := UnitTable[unit]
-	if !ok {
-		panic("illegal diskspace unit")
+		return fmt.Sprintf("%s.%0*d %s", bits, prec, 0, name)
 	}
 
-	format := fmt.Sprintf("%%.%df %s", prec, unit)
-	return fmt.Sprintf(format, d.quotient(u))
+	return fmt.Sprintf("%.*f %s", prec, d.In(u), name)
 }
 
 // Format implements fmt.Formatter. Supported verbs:
@@ -208,39 +302,37 @@ func (d Size) FormatUnitString(unit string, precision ...int) string {
 //   - %M for metric byte units (KB, MB, ...)
 //   - %m for metric bit units (Kb, Mb, ...)
 //   - %d for the raw int64 value
-//   - %s for a string representation similar to %B but ignoring precision
+//   - %s and %v for a string representation similar to %B but ignoring
+//     precision, and %q for the same quoted
+//
+// Width, flags and padding apply to the whole "value unit" text. The + flag
+// forces a sign on non-negative values, which is useful for deltas: %+.2B
+// prints "+1.20 GiB". The space flag leaves a space for the sign instead. The
+// # flag selects a compact form without a space, such as "1.20GiB". The - flag
+// pads on the right, so %-10B aligns sizes in a table column.
 func (d Size) Format(f fmt.State, verb rune) {
-	precision, fixed := f.Precision()
-	var unit string
-
-	switch verb {
-	case 'B':
-		unit = d.bestUnit(FormatBinaryByte)
-	case 'b':
-		unit = d.bestUnit(FormatBinaryBit)
-	case 'M':
-		unit = d.bestUnit(FormatMetricByte)
-	case 'm':
-		unit = d.bestUnit(FormatMetricBit)
-	case 'd':
-		fmt.Fprint(f, int64(d))
-		return
-	default:
-		fmt.Fprint(f, d.String())
-		return
-	}
+	writeFormatted(f, verb, d.formatVerb(f, verb), d >= 0)
+}
 
-	if fixed {
-		fmt.Fprint(f, d.FormatUnitString(unit, precision))
-		return
+// formatVerb returns the text of d for a verb of Format, before the flags and
+// width are applied.
+func (d Size) formatVerb(f fmt.State, verb rune) string {
+	if verb == 'd' {
+		return strconv.FormatInt(int64(d), 10)
 	}
-
-	if unit == "B" || unit == "b" {
-		fmt.Fprint(f, d.FormatUnitString(unit))
-		return
+	family, ok := formatFamily(verb)
+	if !ok {
+		return d.String()
 	}
 
-	fmt.Fprint(f, d.FormatUnitString(unit, 2))
+	unit := d.bestUnit(family)
+	if precision, fixed := f.Precision(); fixed {
+		return d.FormatIn(unit, precision)
+	}
+	if unit == UnitByte || unit == UnitBit {
+		return d.FormatIn(unit)
+	}
+	return d.FormatIn(unit, 2)
 }
 
 // String returns the default string representation of the Size.
@@ -250,80 +342,116 @@ func (d Size) Format(f fmt.State, verb rune) {
 func (d Size) String() string {
 	unit := d.bestUnit(FormatBinaryByte)
 	switch unit {
-	case "b", "B":
-		return d.FormatUnitString(unit)
+	case UnitBit, UnitByte:
+		return d.FormatIn(unit)
 	default:
-		return d.FormatUnitString(unit, 2)
+		return d.FormatIn(unit, 2)
 	}
 }
 
-type pair struct {
-	name  string
-	value Size
+// Exact returns a lossless string representation of the Size.
+//
+// It uses the same unit as String but prints every significant decimal place,
+// so ParseSize(d.Exact()) always returns d.
+func (d Size) Exact() string {
+	return d.FormatIn(d.bestUnit(FormatBinaryByte), ExactPrecision)
+}
+
+// exactString formats the Size in the non-base unit u with the shortest
+// decimal representation that is exact, writing name as the unit.
+func (d Size) exactString(u Unit, name string) string {
+	// Units are 2^a * 5^b bytes with a, b < 64, so 64 places always suffice.
+	value := new(big.Rat).SetFrac64(int64(d), int64(u.Size())).FloatString(64)
+	value = strings.TrimRight(value, "0")
+	value = strings.TrimSuffix(value, ".")
+	return value + " " + name
 }
 
 var (
-	metricBytes = []pair{
-		{"B", Byte},
-		{"kB", KB},
-		{"MB", MB},
-		{"GB", GB},
-		{"TB", TB},
-		{"PB", PB},
-		{"EB", EB},
+	metricBytes = []Unit{
+		UnitByte, UnitKB, UnitMB, UnitGB, UnitTB, UnitPB, UnitEB,
 	}
-	metricBits = []pair{
-		{"b", 0},
-		{"kb", Kb},
-		{"Mb", Mb},
-		{"Gb", Gb},
-		{"Tb", Tb},
-		{"Pb", Pb},
-		{"Eb", Eb},
+	metricBits = []Unit{
+		UnitBit, UnitKb, UnitMb, UnitGb, UnitTb, UnitPb, UnitEb,
 	}
-	binaryBytes = []pair{
-		{"B", Byte},
-		{"kiB", KiB},
-		{"MiB", MiB},
-		{"GiB", GiB},
-		{"TiB", TiB},
-		{"PiB", PiB},
-		{"EiB", EiB},
+	binaryBytes = []Unit{
+		UnitByte, UnitKiB, UnitMiB, UnitGiB, UnitTiB, UnitPiB, UnitEiB,
 	}
-	binaryBits = []pair{
-		{"b", 0},
-		{"kib", Kib},
-		{"Mib", Mib},
-		{"Gib", Gib},
-		{"Tib", Tib},
-		{"Pib", Pib},
+	binaryBits = []Unit{
+		UnitBit, UnitKib, UnitMib, UnitGib, UnitTib, UnitPib, UnitEib,
 	}
 )
 
-// bestUnit returns the most appropriate unit name for the Size within the given
-// unit family.
-//
-// The returned unit is chosen such that the formatted value is less than the
-// next larger unit.
-func (d Size) bestUnit(u FormatUnit) string {
-	var unitList []pair
-
+// unitList returns the units of the given family, smallest first.
+func unitList(u FormatUnit) []Unit {
 	switch u {
 	case FormatBinaryByte:
-		unitList = binaryBytes
+		return binaryBytes
 	case FormatMetricByte:
-		unitList = metricBytes
+		return metricBytes
 	case FormatBinaryBit:
-		unitList = binaryBits
+		return binaryBits
 	case FormatMetricBit:
-		unitList = metricBits
+		return metricBits
 	default:
 		panic("invalid unit kind")
 	}
+}
 
-	p := islices.LastItemFunc(unitList, func(a pair) bool {
-		return a.value <= d
+// bestUnit returns the most appropriate unit for the Size within the given
+// unit family.
+//
+// The returned unit is chosen such that the magnitude of the formatted value is
+// less than the next larger unit, so negative sizes pick the same unit as their
+// absolute value.
+func (d Size) bestUnit(u FormatUnit) Unit {
+	return islices.LastItemFunc(unitList(u), func(a Unit) bool {
+		if d < 0 {
+			return -a.Size() >= d
+		}
+		return a.Size() <= d
 	})
+}
+
+// Components formats the Size as a sum of whole units of the given family,
+// largest first, the way du or time.Duration would: 1.5 GiB is written as
+// "1 GiB 512 MiB". Units with a zero count are left out and a negative Size
+// is prefixed with a single minus sign. The result parses back with ParseSize.
+func (d Size) Components(u FormatUnit) string {
+	units := unitList(u)
+	bits := u == FormatBinaryBit || u == FormatMetricBit
+
+	rest := big.NewInt(int64(d))
+	rest.Abs(rest)
+	if bits {
+		rest.Lsh(rest, 3)
+	}
 
-	return p.name
+	if rest.Sign() == 0 {
+		return "0 " + units[0].String()
+	}
+
+	var parts []string
+	for i := len(units) - 1; i >= 0; i-- {
+		size := big.NewInt(int64(units[i].Size()))
+		if bits {
+			if units[i] == UnitBit {
+				size.SetInt64(1)
+			} else {
+				size.Lsh(size, 3)
+			}
+		}
+
+		count := new(big.Int)
+		count.QuoRem(rest, size, rest)
+		if count.Sign() != 0 {
+			parts = append(parts, count.String()+" "+units[i].String())
+		}
+	}
+
+	out := strings.Join(parts, " ")
+	if d < 0 {
+		return "-" + out
+	}
+	return out
 }
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -34,6 +35,11 @@ func TestParseSize(t *testing.T) {
 		{"kilobit", "8Kb", KB, false}, // 8 kilobits == 1 kilobyte
 		{"megabit", "16Mb", 2 * MB, false},
 
+		// --- bits ---
+		{"bits", "16b", 2, false},
+		{"bits with space", "8 b", Byte, false},
+		{"sub-byte bits round", "3b", 0, false},
+
 		// --- binary bit units ---
 		{"kibibit", "8Kib", KiB, false},
 		{"mebibit", "16Mib", 2 * MiB, false},
@@ -52,12 +58,49 @@ func TestParseSize(t *testing.T) {
 		{"exbibyte", "1EiB", EiB, false},
 		{"petabit", "8Pb", PB, false},
 
+		// --- compound values ---
+		{"compound binary", "1GiB512MiB", GiB + 512*MiB, false},
+		{"compound with spaces", "1 GiB 512 MiB", GiB + 512*MiB, false},
+		{"compound metric", "3TB 200GB", 3*TB + 200*GB, false},
+		{"compound trailing bytes", "1KiB 10", KiB + 10, false},
+		{"compound any order", "512MiB 1GiB", GiB + 512*MiB, false},
+		{"compound with fraction", "1.5GiB 1B", GiB + 512*MiB + 1, false},
+		{"compound negative", "-1GiB512MiB", -(GiB + 512*MiB), false},
+		{"compound rounds once", "0.5B 0.5B", 1, false},
+		{"compound overflow", "8EiB 8EiB", 0, true},
+		{"compound missing unit", "1 2GiB", 0, true},
+		{"compound inner sign", "1GiB -512MiB", 0, true},
+		{"compound bad unit", "1GiB 2XB", 0, true},
+		{"compound dangling unit", "1GiB MiB", 0, true},
+
 		// --- invalid formats ---
 		{"empty", "", 0, true},
+		{"minus sign only", "-", 0, true},
+		{"plus sign only", "+", 0, true},
+		{"spaced sign only", " - ", 0, true},
 		{"no number", "KB", 0, true},
 		{"no unit letters", "10_", 0, true},
 		{"unknown unit", "10XB", 0, true},
-		{"float not supported", "1.5GB", 0, true},
+		{"double dot", "1.2.3GB", 0, true},
+		{"overflow", "9EiB", 0, true},
+		{"negative overflow", "-9EiB", 0, true},
+		{"max int64", "9223372036854775807", Size(1<<63 - 1), false},
+		{"past max int64", "9223372036854775808", 0, true},
+
+		// --- fractional and scientific notation ---
+		{"fractional gibibyte", "1.5 GiB", GiB + 512*MiB, false},
+		{"fractional megabyte", "0.25MB", 250 * KB, false},
+		{"leading dot", ".5KiB", 512, false},
+		{"scientific kilobyte", "2e3 kB", 2 * MB, false},
+		{"scientific negative exponent", "1.5e-3 MB", 1500, false},
+		{"negative fraction", "-1.5KiB", -1536, false},
+		{"String output", "2.50 GiB", 2*GiB + 512*MiB, false},
+		{"fractional bits", "1.5Kb", 188, false}, // 187.5 bytes
+		{"sub-byte rounds down", "1.4B", 1, false},
+		{"sub-byte half rounds up", "0.5B", 1, false},
+		{"negative half rounds away", "-0.5B", -1, false},
+		{"fractional overflow", "8.1EiB", 0, true},
+		{"huge exponent", "1e999999999B", 0, true},
 	}
 
 	for _, tt := range tests {
@@ -82,6 +125,14 @@ func TestParseSize(t *testing.T) {
 	}
 }
 
+func TestParseSize_Overflow(t *testing.T) {
+	for _, input := range []string{"9EiB", "-9EiB", "8EiB 8EiB"} {
+		if _, err := ParseSize(input); !errors.Is(err, ErrOverflow) {
+			t.Errorf("ParseSize(%q) = %v, want ErrOverflow", input, err)
+		}
+	}
+}
+
 func TestSize_quotient(t *testing.T) {
 	tests := []struct {
 		name string
@@ -126,7 +177,20 @@ func TestFormatUnitString(t *testing.T) {
 		{"raw bits with precision", 1, 3, "b", "8.000 b"},
 		{"metric bytes", 1500, 2, "kB", "1.50 kB"},
 		{"binary bytes", 1536, 2, "KiB", "1.50 KiB"},
-		{"metric bits", 1000, 2, "kb", "8.00 kb"},
+		{"metric bits", 1000, 2, "kb", "8.00 Kb"},
+		{"binary bits", 1024, 2, "kib", "8.00 Kib"},
+		{"negative bytes", -42, 2, "B", "-42.00 B"},
+		{"negative bits", -1, 0, "b", "-8 b"},
+		{"negative metric", -1500, 2, "kB", "-1.50 kB"},
+		{"negative metric bits", -1000, 1, "Kb", "-8.0 Kb"},
+		{"exact bytes", 42, ExactPrecision, "B", "42 B"},
+		{"exact bits", 1, ExactPrecision, "b", "8 b"},
+		{"exact binary", GiB + 1, ExactPrecision, "GiB",
+			"1.000000000931322574615478515625 GiB"},
+		{"exact trims zeros", 1536, ExactPrecision, "KiB", "1.5 KiB"},
+		{"exact integer", 2 * MB, ExactPrecision, "MB", "2 MB"},
+		{"exact metric bits", 1, ExactPrecision, "Kb", "0.008 Kb"},
+		{"exact negative", -1536, ExactPrecision, "KiB", "-1.5 KiB"},
 	}
 
 	for _, tt := range tests {
@@ -150,13 +214,19 @@ func TestBestUnit(t *testing.T) {
 		{"binary KiB", 1024, FormatBinaryByte, "kiB"},
 		{"binary MiB", 5 * MiB, FormatBinaryByte, "MiB"},
 		{"metric KB", 1000, FormatMetricByte, "kB"},
-		{"metric bits", 1000, FormatMetricBit, "kb"},
-		{"binary bits", 1024, FormatBinaryBit, "kib"},
+		{"metric bits", 1000, FormatMetricBit, "Kb"},
+		{"binary bits", 1024, FormatBinaryBit, "Kib"},
+		{"negative bytes", -512, FormatBinaryByte, "B"},
+		{"negative KiB", -1024, FormatBinaryByte, "kiB"},
+		{"negative GiB", -3 * GiB, FormatBinaryByte, "GiB"},
+		{"negative metric", -1500 * MB, FormatMetricByte, "GB"},
+		{"negative bits", -KB, FormatMetricBit, "Kb"},
+		{"min int64", Size(-1 << 63), FormatBinaryByte, "EiB"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.size.bestUnit(tt.unit)
+			got := tt.size.bestUnit(tt.unit).String()
 			if got != tt.want {
 				t.Fatalf("got %q, want %q", got, tt.want)
 			}
@@ -174,6 +244,10 @@ func TestString(t *testing.T) {
 		{"bytes", 42, "42 B"},
 		{"KiB formatting", 1536, "1.50 kiB"},
 		{"MiB formatting", 5 * MiB, "5.00 MiB"},
+		{"negative bytes", -42, "-42 B"},
+		{"negative GiB", -3 * GiB, "-3.00 GiB"},
+		{"negative fraction", -1536, "-1.50 kiB"},
+		{"min int64", Size(-1 << 63), "-8.00 EiB"},
 	}
 
 	for _, tt := range tests {
@@ -195,10 +269,19 @@ func TestFmtFormatting(t *testing.T) {
 	}{
 		{"binary byte verb", "%B", 1536, "1.50 kiB"},
 		{"metric byte verb", "%M", 1500, "1.50 kB"},
-		{"binary bit verb", "%b", 1024, "8.00 kib"},
-		{"metric bit verb", "%m", 1000, "8.00 kb"},
+		{"binary bit verb", "%b", 1024, "8.00 Kib"},
+		{"metric bit verb", "%m", 1000, "8.00 Kb"},
 		{"raw int", "%d", 1234, "1234"},
 		{"precision override", "%.1B", 1536, "1.5 kiB"},
+		{"exbibit", "%b", EiB, "8.00 Eib"},
+		{"negative binary", "%B", -GiB, "-1.00 GiB"},
+		{"negative metric", "%.1M", -1500, "-1.5 kB"},
+		{"negative bits", "%m", -KB, "-8.00 Kb"},
+		{"negative bytes as bits", "%b", -1, "-8 b"},
+		{"forced sign", "%+.2B", GiB + 205*MiB, "+1.20 GiB"},
+		{"forced sign negative", "%+.2B", -GiB, "-1.00 GiB"},
+		{"forced sign zero", "%+B", 0, "+0 B"},
+		{"forced sign raw", "%+d", 5, "+5"},
 	}
 
 	for _, tt := range tests {
@@ -210,3 +293,126 @@ func TestFmtFormatting(t *testing.T) {
 		})
 	}
 }
+
+func TestExact(t *testing.T) {
+	tests := []struct {
+		name string
+		size Size
+		want string
+	}{
+		{"zero", 0, "0 B"},
+		{"bytes", 42, "42 B"},
+		{"KiB", 1536, "1.5 kiB"},
+		{"MiB plus a byte", MiB + 1, "1.00000095367431640625 MiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.size.Exact()
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+var roundTripSizes = []Size{
+	0, 1, 7, 999, 1000, 1023, 1024, 1536, 123456789,
+	MB + 1, GiB - 1, 5*TB + 3, 7*PiB + 12345, EiB + 1,
+	Size(1<<63 - 1), -1, -1536,
+}
+
+func TestRoundTripExact(t *testing.T) {
+	units := []string{"B", "b"}
+	for unit := range UnitTable {
+		units = append(units, unit)
+	}
+
+	for _, size := range roundTripSizes {
+		formatted := []string{size.Exact()}
+		for _, unit := range units {
+			formatted = append(formatted,
+				size.FormatUnitString(unit, ExactPrecision))
+		}
+
+		for _, s := range formatted {
+			got, err := ParseSize(s)
+			if err != nil {
+				t.Fatalf("ParseSize(%q) unexpected error: %v", s, err)
+			}
+			if got != size {
+				t.Fatalf("ParseSize(%q) = %d, want %d", s, got, size)
+			}
+		}
+	}
+}
+
+func TestRoundTripFormatted(t *testing.T) {
+	verbs := []string{"%B", "%b", "%M", "%m", "%d", "%s", "%v", "%.1B", "%.3m"}
+
+	for _, size := range roundTripSizes {
+		// Rounding near the int64 limit may print a value that overflows.
+		if size < 0 || size > 7*EiB {
+			continue
+		}
+
+		formatted := []string{size.String()}
+		for _, verb := range verbs {
+			formatted = append(formatted, fmt.Sprintf(verb, size))
+		}
+
+		for _, s := range formatted {
+			got, err := ParseSize(s)
+			if err != nil {
+				t.Fatalf("ParseSize(%q) unexpected error: %v", s, err)
+			}
+
+			// Rounded output may only be off by half of its last digit.
+			diff := float64(got - size)
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff > 0.05*float64(size)+1 {
+				t.Fatalf("ParseSize(%q) = %d, want about %d", s, got, size)
+			}
+		}
+	}
+}
+
+func TestComponents(t *testing.T) {
+	tests := []struct {
+		name string
+		size Size
+		unit FormatUnit
+		want string
+	}{
+		{"zero bytes", 0, FormatBinaryByte, "0 B"},
+		{"zero bits", 0, FormatMetricBit, "0 b"},
+		{"bytes only", 42, FormatBinaryByte, "42 B"},
+		{"binary", GiB + 512*MiB, FormatBinaryByte, "1 GiB 512 MiB"},
+		{"skips zero units", GiB + 3, FormatBinaryByte, "1 GiB 3 B"},
+		{"metric", 3*TB + 200*GB + 1, FormatMetricByte, "3 TB 200 GB 1 B"},
+		{"negative", -(KiB + 1), FormatBinaryByte, "-1 kiB 1 B"},
+		{"metric bits", KB + 1, FormatMetricBit, "8 Kb 8 b"},
+		{"binary bits", 2*KiB + 1, FormatBinaryBit, "16 Kib 8 b"},
+		{"max", Size(1<<63 - 1), FormatBinaryByte,
+			"7 EiB 1023 PiB 1023 TiB 1023 GiB 1023 MiB 1023 kiB 1023 B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.size.Components(tt.unit)
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+
+			back, err := ParseSize(got)
+			if err != nil {
+				t.Fatalf("ParseSize(%q) unexpected error: %v", got, err)
+			}
+			if back != tt.size {
+				t.Fatalf("ParseSize(%q) = %d, want %d", got, back, tt.size)
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package data
 
 import (
-	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+
+	islices "github.com/Nadim147c/real-go/internal/slices"
 )
 
 // Speed represents a quantity of data transfer in bytes per second.
@@ -21,7 +26,8 @@ func NewSpeed(amount Size, dur time.Duration) Speed {
 	return speed
 }
 
-// NewSpeedE creates a speed from given amount and time.
+// NewSpeedE creates a speed from given amount and time, truncating any
+// fraction of a byte per second. Use NewPreciseSpeed to keep it.
 // It returns an error instead of panicking.
 func NewSpeedE(amount Size, dur time.Duration) (Speed, error) {
 	if dur < 0 {
@@ -37,47 +43,183 @@ func NewSpeedE(amount Size, dur time.Duration) (Speed, error) {
 		return 0, fmt.Errorf("negative amount: %d", amount)
 	}
 
-	// overflow check: amount * time.Second
-	if amount > Size(math.MaxInt64)/Size(time.Second) {
-		return 0, errors.New("speed overflows int64")
+	// amount * time.Second needs up to 94 bits, so divide the full product.
+	hi, lo := bits.Mul64(uint64(amount), uint64(time.Second))
+	if hi >= uint64(dur) {
+		return 0, fmt.Errorf("%w: speed overflows uint64", ErrOverflow)
 	}
 
-	bytesPerSecond := (amount * Size(time.Second)) / Size(dur)
+	bytesPerSecond, _ := bits.Div64(hi, lo, uint64(dur))
 	return Speed(bytesPerSecond), nil
 }
 
-var timeTable = map[string]time.Duration{
-	"ns": time.Nanosecond,
-	"µs": time.Nanosecond,
-	"ms": time.Millisecond,
-	"s":  time.Second,
-	"m":  time.Minute,
-	"h":  time.Hour,
+// perUnits maps the accepted spellings of the time unit of a speed, in lower
+// case, to its duration.
+var perUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "nsec": time.Nanosecond,
+	"nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+	"us": time.Microsecond, "usec": time.Microsecond,
+	"µs": time.Microsecond, "μs": time.Microsecond, // micro sign and mu
+	"microsecond": time.Microsecond, "microseconds": time.Microsecond,
+	"ms": time.Millisecond, "msec": time.Millisecond,
+	"millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second,
+	"second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour,
+	"hour": time.Hour, "hours": time.Hour,
+	"d": Day, "day": Day, "days": Day,
+	"w": Week, "wk": Week, "week": Week, "weeks": Week,
+	"mo": Month, "month": Month, "months": Month,
+	"y": Year, "yr": Year, "year": Year, "years": Year,
 }
 
-// ParseSpeed parses a dataspeed to Speed
+// ParseSpeed parses a dataspeed such as "10 MB/s", "100Mbps", "1 Gbit/s",
+// "50 MiB/sec" or "10 GB per hour" to Speed, rounded to the nearest byte per
+// second.
+//
+// The amount is anything ParseSize accepts, and its unit may also be written
+// as a word: "bit", "byte", or a prefixed form such as "kbit", "Mibit" or
+// "GByte", in either case and with an optional plural "s". The time unit
+// follows "/", "per" or "p", as in "bps", and may be spelled "s", "sec",
+// "second", "min", "hour", "day", "month" and so on, from nanoseconds up to
+// years. After "/" it may also be a duration such as "10s" or "1h30m".
+//
+// In the "p" form a lower case "b" always means bits, as is usual for these
+// abbreviations, so "10 mbps" and "10Mbps" are both megabits per second while
+// "10MBps" is megabytes per second.
 func ParseSpeed(s string) (Speed, error) {
+	perSecond, err := parsePerTime(s)
+	if err != nil {
+		return 0, err
+	}
+	if perSecond.Sign() < 0 {
+		return 0, fmt.Errorf("negative dataspeed: %q", s)
+	}
+
+	n := roundRat(perSecond)
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("%w: dataspeed overflows uint64: %q", ErrOverflow, s)
+	}
+	return Speed(n.Uint64()), nil
+}
+
+// parsePerTime parses a dataspeed such as "10 MB/s" into an exact, signed
+// number of bytes per second. Its errors name the part of s that failed.
+func parsePerTime(s string) (*big.Rat, error) {
 	trimmed := strings.TrimSpace(s)
-	perIndex := strings.LastIndexAny(trimmed, "p/")
-	if perIndex < 0 {
-		return 0, fmt.Errorf("invalid dataspeed format: %q", s)
+	amountStr, perStr, abbrev, ok := splitPer(trimmed)
+	if !ok {
+		return nil, fmt.Errorf("missing time unit in dataspeed: %q", s)
 	}
-	sizeStr, durStr := trimmed[:perIndex], trimmed[perIndex+1:]
 
-	dur, ok := timeTable[strings.TrimSpace(durStr)]
+	per, ok := parsePer(strings.TrimSpace(perStr))
 	if !ok {
-		return 0, fmt.Errorf("invalid duration for dataspeed: %q", durStr)
+		return nil, fmt.Errorf(
+			"invalid time unit %q in dataspeed: %q", perStr, s)
 	}
 
-	size, err := ParseSize(sizeStr)
+	lookup := speedUnitBytes
+	if abbrev {
+		lookup = bpsUnitBytes
+	}
+	amount, err := parseBytes(amountStr, lookup)
 	if err != nil {
-		return 0, fmt.Errorf("invalid size for dataspeed: %w", err)
+		return nil, fmt.Errorf("invalid amount in dataspeed %q: %w", s, err)
 	}
-	return NewSpeedE(size, dur)
+
+	perSecond := amount.Mul(amount, big.NewRat(int64(time.Second), 1))
+	return perSecond.Quo(perSecond, big.NewRat(int64(per), 1)), nil
 }
 
-// Size returns the speed as a Size (bytes per second)
+// parsePer parses the time unit of a dataspeed: a name in perUnits or a
+// positive duration such as "10s".
+func parsePer(s string) (time.Duration, bool) {
+	if s != "" && isDigit(s[0]) {
+		d, err := time.ParseDuration(s)
+		return d, err == nil && d > 0
+	}
+	d, ok := perUnits[strings.ToLower(s)]
+	return d, ok
+}
+
+// splitPer splits a dataspeed into its amount and its time unit, which follows
+// the last "/", the last " per ", or the last "p" that is followed by a time
+// unit. abbrev reports the last form, as in "Mbps".
+func splitPer(s string) (amount, per string, abbrev, ok bool) {
+	if i := strings.LastIndexByte(s, '/'); i >= 0 {
+		return s[:i], s[i+1:], false, true
+	}
+	if i := lastIndexFold(s, " per "); i >= 0 {
+		return s[:i], s[i+len(" per "):], false, true
+	}
+	if i := strings.LastIndexByte(s, 'p'); i > 0 {
+		if _, ok := perUnits[strings.ToLower(s[i+1:])]; ok {
+			return s[:i], s[i+1:], true, true
+		}
+	}
+	return "", "", false, false
+}
+
+// lastIndexFold returns the index in s of the last instance of the ASCII
+// substr, ignoring case, or -1. Unlike searching strings.ToLower(s), the index
+// is always an offset into s itself.
+func lastIndexFold(s, substr string) int {
+	for i := len(s) - len(substr); i >= 0; i-- {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// speedUnitBytes looks up a unit of the amount of a dataspeed. Besides the
+// units of ParseUnit, it accepts the words "bit" and "byte", optionally with
+// a prefix such as "k", "Mi" or "G" and a plural "s", in any case.
+func speedUnitBytes(name string) (*big.Rat, bool) {
+	if r, ok := unitBytes(name); ok {
+		return r, true
+	}
+
+	lower := strings.ToLower(name)
+	for _, word := range []struct {
+		name   string
+		symbol string
+	}{{"bits", "b"}, {"bit", "b"}, {"bytes", "B"}, {"byte", "B"}} {
+		prefix, found := strings.CutSuffix(lower, word.name)
+		if !found {
+			continue
+		}
+		if prefix != "" {
+			prefix = strings.ToUpper(prefix[:1]) + prefix[1:]
+		}
+		u, ok := unitNames[prefix+word.symbol]
+		if !ok {
+			return nil, false
+		}
+		return u.bytes(), true
+	}
+	return nil, false
+}
+
+// bpsUnitBytes looks up a unit of the amount of a dataspeed written as in
+// "mbps". Unlike in ParseUnit, an all lower case unit ending in "b" is a bit
+// unit, so "mb" is a megabit and "kib" a kibibit.
+func bpsUnitBytes(name string) (*big.Rat, bool) {
+	prefix, found := strings.CutSuffix(name, "b")
+	if found && prefix != "" && all(prefix, unicode.IsLower) {
+		name = strings.ToUpper(prefix[:1]) + prefix[1:] + "b"
+	}
+	return speedUnitBytes(name)
+}
+
+// Size returns the speed as a Size (bytes per second). Speeds beyond the range
+// of Size are clamped to math.MaxInt64 rather than wrapping to a negative Size.
 func (s Speed) Size() Size {
+	if s > math.MaxInt64 {
+		return math.MaxInt64
+	}
 	return Size(s)
 }
 
@@ -86,18 +228,23 @@ func (s Speed) Size() Size {
 // Supported units include all units supported by Size.FormatUnitString,
 // with "/s" appended for per-second notation.
 func (s Speed) FormatUnitString(unit string, precision ...int) string {
-	if s == 0 {
-		return "0 " + unit + "/s"
-	}
+	u, name := formatUnitName(unit)
+	return s.formatUnit(u, name, islices.OptionalValue(0, precision))
+}
 
-	// Convert to Size and use its FormatUnitString, then append "/s"
-	size := s.Size()
-	formatted := size.FormatUnitString(unit, precision...)
+// FormatIn formats the Speed in the given unit per second, like
+// FormatUnitString.
+func (s Speed) FormatIn(u Unit, precision ...int) string {
+	return s.formatUnit(u, u.String(), islices.OptionalValue(0, precision))
+}
 
-	// Append "/s" to the formatted string
-	// The formatted string is in format "value unit" or "value.fraction unit"
-	// We need to insert "/s" before the space or at the end
-	return formatted + "/s"
+// formatUnit formats the Speed in u per second, writing name as the unit.
+// Speeds beyond the range of Size are formatted as a BigSize.
+func (s Speed) formatUnit(u Unit, name string, prec int) string {
+	if s > math.MaxInt64 {
+		return s.bigSize().formatUnit(bigUnit{name, u.bytes()}, prec) + "/s"
+	}
+	return Size(s).formatUnit(u, name, prec) + "/s"
 }
 
 // Format implements fmt.Formatter. Supported verbs:
@@ -106,10 +253,32 @@ func (s Speed) FormatUnitString(unit string, precision ...int) string {
 //   - %M for metric byte units per second (kB/s, MB/s, ...)
 //   - %m for metric bit units per second (Kb/s, Mb/s, ...)
 //   - %d for the raw uint64 value
-//   - %s for a string representation similar to %B but ignoring precision
+//   - %s and %v for a string representation similar to %B but ignoring
+//     precision, and %q for the same quoted
+//
+// Width and flags apply to the whole text as in Size.Format, so %#-12.1M
+// prints "1.5MB/s" padded to 12 columns. Use Per to format the speed per
+// another period, such as "3.20 TB/day".
 func (s Speed) Format(f fmt.State, verb rune) {
-	s.Size().Format(f, verb)
-	fmt.Fprint(f, "/s")
+	writeFormatted(f, verb, s.formatVerb(f, verb), true)
+}
+
+// formatVerb returns the text of s for a verb of Format, before the flags and
+// width are applied. Speeds beyond the range of Size are formatted as a
+// BigSize.
+func (s Speed) formatVerb(f fmt.State, verb rune) string {
+	switch {
+	case verb == 'd':
+		return strconv.FormatUint(uint64(s), 10) + "/s"
+	case s > math.MaxInt64:
+		return s.bigSize().formatVerb(f, verb) + "/s"
+	}
+	return s.Size().formatVerb(f, verb) + "/s"
+}
+
+// bigSize returns the speed as a BigSize, for speeds beyond the range of Size.
+func (s Speed) bigSize() BigSize {
+	return BigSize{new(big.Int).SetUint64(uint64(s))}
 }
 
 // String returns the default string representation of the Speed.
@@ -117,9 +286,23 @@ func (s Speed) Format(f fmt.State, verb rune) {
 // It uses binary byte units per second and prints with two decimal places,
 // except for raw bytes per second, which are printed as integers.
 func (s Speed) String() string {
+	if s > math.MaxInt64 {
+		return s.bigSize().String() + "/s"
+	}
 	return s.Size().String() + "/s"
 }
 
+// Exact returns a lossless string representation of the Speed.
+//
+// It uses the same unit as String but prints every significant decimal place,
+// so ParseSpeed(s.Exact()) always returns s.
+func (s Speed) Exact() string {
+	if s > math.MaxInt64 {
+		return s.bigSize().Exact() + "/s"
+	}
+	return s.Size().Exact() + "/s"
+}
+
 // BytesPerSecond returns the speed in bytes per second as a uint64
 func (s Speed) BytesPerSecond() uint64 {
 	return uint64(s)
@@ -130,8 +313,7 @@ func (s Speed) KilobitsPerSecond() float64 {
 	if s == 0 {
 		return 0
 	}
-	size := s.Size()
-	return float64(size) / float64(Kb)
+	return float64(s) / float64(Kb)
 }
 
 // MegabitsPerSecond returns the speed in megabits per second (metric)
@@ -139,8 +321,7 @@ func (s Speed) MegabitsPerSecond() float64 {
 	if s == 0 {
 		return 0
 	}
-	size := s.Size()
-	return float64(size) / float64(Mb)
+	return float64(s) / float64(Mb)
 }
 
 // KilobytesPerSecond returns the speed in kilobytes per second (metric)
@@ -148,8 +329,7 @@ func (s Speed) KilobytesPerSecond() float64 {
 	if s == 0 {
 		return 0
 	}
-	size := s.Size()
-	return float64(size) / float64(KB)
+	return float64(s) / float64(KB)
 }
 
 // MegabytesPerSecond returns the speed in megabytes per second (metric)
@@ -157,8 +337,7 @@ func (s Speed) MegabytesPerSecond() float64 {
 	if s == 0 {
 		return 0
 	}
-	size := s.Size()
-	return float64(size) / float64(MB)
+	return float64(s) / float64(MB)
 }
 
 // KibibitsPerSecond returns the speed in kibibits per second (binary)
@@ -166,8 +345,7 @@ func (s Speed) KibibitsPerSecond() float64 {
 	if s == 0 {
 		return 0
 	}
-	size := s.Size()
-	return float64(size) / float64(Kib)
+	return float64(s) / float64(Kib)
 }
 
 // MebibitsPerSecond returns the speed in mebibits per second (binary)
@@ -175,8 +353,7 @@ func (s Speed) MebibitsPerSecond() float64 {
 	if s == 0 {
 		return 0
 	}
-	size := s.Size()
-	return float64(size) / float64(Mib)
+	return float64(s) / float64(Mib)
 }
 
 // KibibytesPerSecond returns the speed in kibibytes per second (binary)
@@ -184,8 +361,7 @@ func (s Speed) KibibytesPerSecond() float64 {
 	if s == 0 {
 		return 0
 	}
-	size := s.Size()
-	return float64(size) / float64(KiB)
+	return float64(s) / float64(KiB)
 }
 
 // MebibytesPerSecond returns the speed in mebibytes per second (binary)
@@ -193,6 +369,5 @@ func (s Speed) MebibytesPerSecond() float64 {
 	if s == 0 {
 		return 0
 	}
-	size := s.Size()
-	return float64(size) / float64(MiB)
+	return float64(s) / float64(MiB)
 }
@@ -1,6 +1,9 @@
 package data
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 )
@@ -55,6 +58,18 @@ func TestNewSpeed(t *testing.T) {
 			dur:      500 * time.Millisecond,
 			expected: Speed(1000 * MB), // 1000 MB/s
 		},
+		{
+			name:     "8 EiB in 1 hour",
+			amount:   Size(math.MaxInt64),
+			dur:      time.Hour,
+			expected: Speed(math.MaxInt64 / 3600),
+		},
+		{
+			name:   "8 EiB in 1 nanosecond - overflow should panic",
+			amount: Size(math.MaxInt64),
+			dur:    time.Nanosecond,
+			panic:  true,
+		},
 		{
 			name:   "negative duration - should panic",
 			amount: MB,
@@ -120,14 +135,174 @@ func TestParseSpeed(t *testing.T) {
 			want:    Speed(0), // wraps; design-dependent
 			wantErr: true,
 		},
+		{
+			name:  "decimal binary units",
+			input: "1.50 kiB/s",
+			want:  Speed(1536),
+		},
+		{
+			name:  "bits per second",
+			input: "8 b/s",
+			want:  Speed(1),
+		},
+		{
+			name:  "metric bits per second",
+			input: "8.00 Kb/s",
+			want:  Speed(KB),
+		},
+		{
+			name:  "raw value",
+			input: "1234/s",
+			want:  Speed(1234),
+		},
+		{
+			name:  "megabits per second",
+			input: "100Mbps",
+			want:  Speed(100 * MB / 8),
+		},
+		{
+			name:  "megabytes per second",
+			input: "2MBps",
+			want:  Speed(2 * MB),
+		},
+		{
+			name:  "lower case megabits per second",
+			input: "10 mbps",
+			want:  Speed(10 * MB / 8),
+		},
+		{
+			name:  "lower case kilobits per second",
+			input: "8kbps",
+			want:  Speed(KB),
+		},
+		{
+			name:  "kilobits per second",
+			input: "8 Kbps",
+			want:  Speed(KB),
+		},
+		{
+			name:  "lower case binary bits per second",
+			input: "8 mibps",
+			want:  Speed(MiB),
+		},
+		{
+			name:  "lower case gigabits per hour",
+			input: "36 gbph",
+			want:  Speed(1250 * KB),
+		},
+		{
+			name:  "lower case bytes with a slash",
+			input: "1 kb/s",
+			want:  Speed(KB),
+		},
+		{
+			name:  "bit word",
+			input: "1 Gbit/s",
+			want:  Speed(GB / 8),
+		},
+		{
+			name:  "binary bit word",
+			input: "8 Mibits/s",
+			want:  Speed(MiB),
+		},
+		{
+			name:  "plain byte words",
+			input: "64 bytes per second",
+			want:  64,
+		},
+		{
+			name:  "spelled second",
+			input: "50 MiB/sec",
+			want:  Speed(50 * MiB),
+		},
+		{
+			name:  "per hour",
+			input: "10 GB/hour",
+			want:  Speed(2777778),
+		},
+		{
+			name:  "per minute with per",
+			input: "6 KByte per min",
+			want:  Speed(100),
+		},
+		{
+			name:  "decimal bits",
+			input: "1.5 Gb/s",
+			want:  Speed(187500 * KB),
+		},
+		{
+			name:  "microseconds",
+			input: "1 B/µs",
+			want:  Speed(MB),
+		},
+		{
+			name:  "greek mu",
+			input: "1 B/μs",
+			want:  Speed(MB),
+		},
+		{
+			name:  "fraction of a byte per millisecond",
+			input: "0.5 B/ms",
+			want:  Speed(500),
+		},
+		{
+			name:  "compound amount",
+			input: "1 MiB 512 KiB/s",
+			want:  Speed(1536 * KiB),
+		},
+		{
+			name:    "invalid unit word",
+			input:   "1 Xbit/s",
+			wantErr: true,
+		},
+		{
+			name:    "overflow",
+			input:   "100 EB/s",
+			wantErr: true,
+		},
 		{
 			name:    "missing separator",
 			input:   "1KBs",
 			wantErr: true,
 		},
+		{
+			name:  "per day",
+			input: "3.2 TB/day",
+			want:  Speed(37037037),
+		},
+		{
+			name:  "per month",
+			input: "2629746 kB per month",
+			want:  Speed(KB),
+		},
+		{
+			name:  "per duration",
+			input: "5 MB/10s",
+			want:  Speed(500 * KB),
+		},
 		{
 			name:    "invalid duration",
-			input:   "1KB/day",
+			input:   "1KB/fortnight",
+			wantErr: true,
+		},
+		{
+			name:  "upper case per",
+			input: "2 MB PER s",
+			want:  Speed(2 * MB),
+		},
+		{
+			name:    "rune with a longer lower case",
+			input:   "ȺȺȺȺȺȺȺȺȺȺ per s",
+			wantErr: true,
+		},
+		{
+			name:    "rune with a shorter lower case",
+			input:   "5 MBİ per s",
+			wantErr: true,
+		},
+		{
+			name:    "negative duration",
+			input:   "1KB/-1s",
 			wantErr: true,
 		},
 		{
@@ -140,6 +315,11 @@ func TestParseSpeed(t *testing.T) {
 			input:   "",
 			wantErr: true,
 		},
+		{
+			name:    "sign only",
+			input:   "-/s",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -163,6 +343,28 @@ func TestParseSpeed(t *testing.T) {
 	}
 }
 
+func TestParseSpeed_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"10 MB", `missing time unit in dataspeed: "10 MB"`},
+		{"10 MB/fortnight", `invalid time unit "fortnight" in dataspeed`},
+		{"10 XB/s", `invalid amount in dataspeed "10 XB/s": invalid input unit`},
+		{"ten MB/s", `invalid amount in dataspeed "ten MB/s"`},
+		{"-1 MB/s", `negative dataspeed: "-1 MB/s"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseSpeed(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got error %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestSpeed_FormatUnitString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -178,6 +380,12 @@ func TestSpeed_FormatUnitString(t *testing.T) {
 			unit:     "B",
 			expected: "0 B/s",
 		},
+		{
+			name:     "0 with a lower case bit unit",
+			speed:    0,
+			unit:     "kb",
+			expected: "0 Kb/s",
+		},
 		{
 			name:     "1024 B/s",
 			speed:    Speed(1024),
@@ -282,6 +490,19 @@ func TestSpeed_FormatUnitString(t *testing.T) {
 	}
 }
 
+func TestSpeed_FormatUnitStringPanics(t *testing.T) {
+	for _, speed := range []Speed{0, 1024} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FormatUnitString(%q) on %d did not panic", "XB", speed)
+				}
+			}()
+			speed.FormatUnitString("XB")
+		}()
+	}
+}
+
 func TestSpeed_String(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -328,6 +549,11 @@ func TestSpeed_String(t *testing.T) {
 			speed:    Speed(2*GiB) + Speed(512*MiB),
 			expected: "2.50 GiB/s",
 		},
+		{
+			name:     "beyond int64",
+			speed:    Speed(math.MaxUint64),
+			expected: "16.00 EiB/s",
+		},
 	}
 
 	for _, tt := range tests {
@@ -339,3 +565,93 @@ func TestSpeed_String(t *testing.T) {
 		})
 	}
 }
+
+func TestSpeed_BeyondInt64(t *testing.T) {
+	s := Speed(math.MaxUint64)
+	if got, want := s.Size(), Size(math.MaxInt64); got != want {
+		t.Errorf("Size() = %d, want %d", got, want)
+	}
+	if got, want := fmt.Sprintf("%d", s), "18446744073709551615/s"; got != want {
+		t.Errorf("Sprintf(%%d) = %q, want %q", got, want)
+	}
+
+	got, err := ParseSpeed(s.Exact())
+	if err != nil {
+		t.Fatalf("ParseSpeed(%q) unexpected error: %v", s.Exact(), err)
+	}
+	if got != s {
+		t.Errorf("ParseSpeed(%q) = %d, want %d", s.Exact(), got, s)
+	}
+
+	formats := []struct {
+		got, want string
+	}{
+		{s.FormatUnitString("B"), "18446744073709551615 B/s"},
+		{s.FormatUnitString("b"), "147573952589676412920 b/s"},
+		{s.FormatUnitString("EiB", 2), "16.00 EiB/s"},
+		{s.FormatIn(UnitGB, 3), "18446744073.710 GB/s"},
+	}
+	for _, f := range formats {
+		if f.got != f.want {
+			t.Errorf("got %q, want %q", f.got, f.want)
+		}
+	}
+	for _, unit := range []string{"B", "b", "kB", "Kib", "EiB", "Eb"} {
+		text := s.FormatUnitString(unit, ExactPrecision)
+		if got, err := ParseSpeed(text); err != nil || got != s {
+			t.Errorf("ParseSpeed(%q) = %d, %v, want %d", text, got, err, s)
+		}
+	}
+
+	rates := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		// float64(s) is 2^64.
+		{"KilobitsPerSecond", s.KilobitsPerSecond(), (1 << 64) / 125.0},
+		{"MegabytesPerSecond", s.MegabytesPerSecond(), (1 << 64) / 1e6},
+		{"MebibytesPerSecond", s.MebibytesPerSecond(), 1 << 44},
+	}
+	for _, r := range rates {
+		if r.got != r.want {
+			t.Errorf("%s() = %g, want %g", r.name, r.got, r.want)
+		}
+	}
+}
+
+func TestSpeed_RoundTrip(t *testing.T) {
+	speeds := []Speed{
+		0, 1, 999, 1024, 1536, Speed(MB) + 1, Speed(GiB) - 1,
+		1 << 63, 1<<63 + 12345,
+	}
+	units := []string{"B", "b", "kB", "kiB", "Kb", "Kib", "MiB", "Gb"}
+
+	for _, speed := range speeds {
+		formatted := []string{speed.Exact()}
+		for _, unit := range units {
+			formatted = append(formatted,
+				speed.FormatUnitString(unit, ExactPrecision))
+		}
+
+		for _, s := range formatted {
+			got, err := ParseSpeed(s)
+			if err != nil {
+				t.Fatalf("ParseSpeed(%q) unexpected error: %v", s, err)
+			}
+			if got != speed {
+				t.Fatalf("ParseSpeed(%q) = %d, want %d", s, got, speed)
+			}
+		}
+
+		for _, verb := range []string{"%B", "%b", "%M", "%m", "%d", "%s"} {
+			s := fmt.Sprintf(verb, speed)
+			if _, err := ParseSpeed(s); err != nil {
+				t.Fatalf("ParseSpeed(%q) unexpected error: %v", s, err)
+			}
+		}
+		if _, err := ParseSpeed(speed.String()); err != nil {
+			t.Fatalf("ParseSpeed(%q) unexpected error: %v", speed, err)
+		}
+	}
+}
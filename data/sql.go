@@ -0,0 +1,96 @@
+package data
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Scan implements sql.Scanner. It accepts int64 and float64 byte counts, and
+// []byte and string columns holding anything ParseSize understands.
+//
+// Size does not implement driver.Valuer, as its Value method returns a plain
+// int64, but database/sql still stores it as its number of bytes. Use RawSize
+// or HumanSize to choose the form explicitly.
+func (d *Size) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*d = Size(v)
+		return nil
+	case float64:
+		n, err := roundFloat(v)
+		if err != nil {
+			return err
+		}
+		if !n.IsInt64() {
+			return fmt.Errorf("%w: size overflows int64: %v", ErrOverflow, v)
+		}
+		*d = Size(n.Int64())
+		return nil
+	case []byte:
+		return d.UnmarshalText(v)
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case nil:
+		return errors.New("cannot scan NULL into data.Size")
+	default:
+		return fmt.Errorf("cannot scan %T into data.Size", src)
+	}
+}
+
+// Value implements driver.Valuer. The Speed is stored as its number of bytes
+// per second; use HumanSpeed to store the string form. Speeds above
+// math.MaxInt64 cannot be stored as a number and return an error.
+func (s Speed) Value() (driver.Value, error) {
+	if s > math.MaxInt64 {
+		return nil, fmt.Errorf(
+			"%w: dataspeed overflows int64: %d", ErrOverflow, uint64(s),
+		)
+	}
+	return int64(s), nil
+}
+
+// Scan implements sql.Scanner. It accepts int64 and float64 values in bytes
+// per second, and []byte and string columns holding a number or anything
+// ParseSpeed understands.
+func (s *Speed) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("negative dataspeed: %d", v)
+		}
+		*s = Speed(v)
+		return nil
+	case float64:
+		n, err := roundFloat(v)
+		if err != nil {
+			return err
+		}
+		if n.Sign() < 0 {
+			return fmt.Errorf("negative dataspeed: %v", v)
+		}
+		if !n.IsUint64() {
+			return fmt.Errorf("%w: dataspeed overflows uint64: %v", ErrOverflow, v)
+		}
+		*s = Speed(n.Uint64())
+		return nil
+	case []byte:
+		return s.UnmarshalText(v)
+	case string:
+		return s.UnmarshalText([]byte(v))
+	case nil:
+		return errors.New("cannot scan NULL into data.Speed")
+	default:
+		return fmt.Errorf("cannot scan %T into data.Speed", src)
+	}
+}
+
+// roundFloat rounds f to the nearest integer like roundRat.
+func roundFloat(f float64) (*big.Int, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("invalid number: %v", f)
+	}
+	return roundRat(new(big.Rat).SetFloat64(f)), nil
+}
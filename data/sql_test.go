@@ -0,0 +1,197 @@
+package data
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Nadim147c/real-go/internal/sqltest"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sqltest.Open()
+	if err != nil {
+		t.Fatalf("open fake database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// sqlRoundTrip stores each value through the fake driver and scans it back
+// into a value of the same type.
+func sqlRoundTrip(t *testing.T, values ...any) {
+	t.Helper()
+	db := openDB(t)
+
+	for _, want := range values {
+		got := reflect.New(reflect.TypeOf(want))
+		if err := db.QueryRow("SELECT ?", want).Scan(got.Interface()); err != nil {
+			t.Fatalf("%T %v: %v", want, want, err)
+		}
+		if got.Elem().Interface() != want {
+			t.Fatalf("%T: got %v, want %v", want, got.Elem(), want)
+		}
+	}
+}
+
+func TestSize_SQLRoundTrip(t *testing.T) {
+	for _, size := range []Size{0, 1, -1536, GiB + 1, Size(1<<63 - 1)} {
+		sqlRoundTrip(t, size, RawSize(size), HumanSize(size))
+	}
+}
+
+func TestSize_Value(t *testing.T) {
+	tests := []struct {
+		name  string
+		value driver.Valuer
+		want  driver.Value
+	}{
+		{"raw", RawSize(1536), int64(1536)},
+		{"human", HumanSize(1536), "1.5 kiB"},
+		{"raw speed", RawSpeed(1536), int64(1536)},
+		{"human speed", HumanSpeed(1536), "1.5 kiB/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.value.Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v != tt.want {
+				t.Fatalf("got %#v, want %#v", v, tt.want)
+			}
+		})
+	}
+
+	if got := (1536 * Byte).Value(); got != 1536 {
+		t.Fatalf("Size.Value() = %d, want 1536", got)
+	}
+}
+
+func TestSize_Scan(t *testing.T) {
+	db := openDB(t)
+
+	tests := []struct {
+		name    string
+		column  any
+		want    Size
+		wantErr bool
+	}{
+		{"int64", int64(1024), KiB, false},
+		{"float64", float64(1536), 1536, false},
+		{"float64 rounds", 1.5, 2, false},
+		{"bytes", []byte("10 MiB"), 10 * MiB, false},
+		{"numeric bytes", []byte("42"), 42, false},
+		{"string", "1.5 GiB", GiB + 512*MiB, false},
+		{"null", nil, 0, true},
+		{"bad string", "10XB", 0, true},
+		{"float overflow", 1e19, 0, true},
+		{"bool", true, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Size
+			err := db.QueryRow("SELECT ?", tt.column).Scan(&got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSize_ScanNull(t *testing.T) {
+	db := openDB(t)
+
+	var got sql.Null[Size]
+	if err := db.QueryRow("SELECT ?", nil).Scan(&got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Valid {
+		t.Fatalf("expected invalid, got %v", got.V)
+	}
+}
+
+func TestSpeed_SQLRoundTrip(t *testing.T) {
+	for _, speed := range []Speed{0, 1, 1536, Speed(GiB) + 1} {
+		sqlRoundTrip(t, speed, RawSpeed(speed), HumanSpeed(speed))
+	}
+	sqlRoundTrip(t, HumanSpeed(1<<64-1))
+}
+
+func TestSpeed_Value(t *testing.T) {
+	if _, err := Speed(1 << 63).Value(); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got %v, want ErrOverflow", err)
+	}
+}
+
+func TestScan_Overflow(t *testing.T) {
+	var size Size
+	if err := size.Scan(1e19); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Size.Scan(1e19) = %v, want ErrOverflow", err)
+	}
+	if err := size.Scan("9 EiB"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Size.Scan(\"9 EiB\") = %v, want ErrOverflow", err)
+	}
+
+	var speed Speed
+	if err := speed.Scan(1e20); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Speed.Scan(1e20) = %v, want ErrOverflow", err)
+	}
+	if err := speed.Scan("20 EB/s"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Speed.Scan(\"20 EB/s\") = %v, want ErrOverflow", err)
+	}
+}
+
+func TestSpeed_Scan(t *testing.T) {
+	db := openDB(t)
+
+	tests := []struct {
+		name    string
+		column  any
+		want    Speed
+		wantErr bool
+	}{
+		{"int64", int64(1024), Speed(KiB), false},
+		{"float64", float64(1536), 1536, false},
+		{"bytes", []byte("10 MB/s"), Speed(10 * MB), false},
+		{"numeric string", "42", 42, false},
+		{"string", "1 KiB/s", Speed(KiB), false},
+		{"negative int64", int64(-1), 0, true},
+		{"negative float64", -1.0, 0, true},
+		{"null", nil, 0, true},
+		{"bad string", "fast", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Speed
+			err := db.QueryRow("SELECT ?", tt.column).Scan(&got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
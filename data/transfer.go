@@ -0,0 +1,112 @@
+package data
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+// maxDuration is the longest time.Duration, returned when a transfer never
+// finishes or takes longer than a Duration can hold.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// TimeFor returns how long it takes to move amount at speed s, rounded up to
+// the next nanosecond so that the transfer is complete by then.
+//
+// A zero or negative amount takes no time. A zero speed never finishes any
+// other amount, so it returns the longest Duration, as does a result that does
+// not fit in a Duration.
+func (s Speed) TimeFor(amount Size) time.Duration {
+	if amount <= 0 {
+		return 0
+	}
+	if s == 0 {
+		return maxDuration
+	}
+
+	hi, lo := bits.Mul64(uint64(amount), uint64(time.Second))
+	if hi >= uint64(s) {
+		return maxDuration
+	}
+	ns, rem := bits.Div64(hi, lo, uint64(s))
+	if ns >= math.MaxInt64 {
+		return maxDuration
+	}
+	if rem != 0 {
+		ns++
+	}
+	return time.Duration(ns)
+}
+
+// AmountIn returns how much data moves at speed s in dur, truncated to whole
+// bytes. A zero or negative duration moves nothing. A result that does not fit
+// in a Size saturates at math.MaxInt64.
+func (s Speed) AmountIn(dur time.Duration) Size {
+	if dur <= 0 {
+		return 0
+	}
+
+	hi, lo := bits.Mul64(uint64(s), uint64(dur))
+	if hi >= uint64(time.Second) {
+		return math.MaxInt64
+	}
+	amount, _ := bits.Div64(hi, lo, uint64(time.Second))
+	if amount > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return Size(amount)
+}
+
+// Over returns the speed at which d moves in dur, truncated to whole bytes per
+// second. Unlike NewSpeedE it never fails: a zero or negative size or duration
+// gives zero, and a speed that does not fit saturates at math.MaxUint64.
+func (d Size) Over(dur time.Duration) Speed {
+	if d <= 0 || dur <= 0 {
+		return 0
+	}
+
+	hi, lo := bits.Mul64(uint64(d), uint64(time.Second))
+	if hi >= uint64(dur) {
+		return math.MaxUint64
+	}
+	perSecond, _ := bits.Div64(hi, lo, uint64(dur))
+	return Speed(perSecond)
+}
+
+// Transfer estimates transfers over a link with a fixed cost per transfer,
+// such as a round trip or a TLS handshake, before data starts to flow.
+type Transfer struct {
+	// Speed is the throughput once data flows.
+	Speed Speed
+	// Latency is paid once per transfer, before the first byte arrives.
+	Latency time.Duration
+}
+
+// TimeFor returns how long it takes to move amount, including the latency.
+// Like Speed.TimeFor, it saturates at the longest Duration.
+func (t Transfer) TimeFor(amount Size) time.Duration {
+	latency := max(t.Latency, 0)
+	if amount <= 0 {
+		return latency
+	}
+	dur := t.Speed.TimeFor(amount)
+	if dur > maxDuration-latency {
+		return maxDuration
+	}
+	return latency + dur
+}
+
+// AmountIn returns how much data a transfer moves within dur, after the
+// latency has been paid.
+func (t Transfer) AmountIn(dur time.Duration) Size {
+	if dur <= t.Latency {
+		return 0
+	}
+	return t.Speed.AmountIn(dur - max(t.Latency, 0))
+}
+
+// Effective returns the average speed of moving amount, with the latency
+// included. It is always slower than Speed when the latency is positive.
+func (t Transfer) Effective(amount Size) Speed {
+	return amount.Over(t.TimeFor(amount))
+}
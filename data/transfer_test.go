@@ -0,0 +1,111 @@
+package data
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSpeed_TimeFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		speed  Speed
+		amount Size
+		want   time.Duration
+	}{
+		{"exact", Speed(MB), 10 * MB, 10 * time.Second},
+		{"rounds up", 3, 1, 333333334},
+		{"one byte per second", 1, KB, 1000 * time.Second},
+		{"zero amount", Speed(MB), 0, 0},
+		{"negative amount", Speed(MB), -MB, 0},
+		{"zero speed", 0, 1, maxDuration},
+		{"zero speed zero amount", 0, 0, 0},
+		{"too long", 1, EB, maxDuration},
+		{"large amount fast link", Speed(GB), 9 * EB, 9e9 * time.Second},
+		{"fastest", math.MaxUint64, math.MaxInt64, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.speed.TimeFor(tt.amount); got != tt.want {
+				t.Errorf("TimeFor(%d) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeed_AmountIn(t *testing.T) {
+	tests := []struct {
+		name  string
+		speed Speed
+		dur   time.Duration
+		want  Size
+	}{
+		{"exact", Speed(MB), 10 * time.Second, 10 * MB},
+		{"truncates", 3, time.Second / 2, 1},
+		{"zero duration", Speed(MB), 0, 0},
+		{"negative duration", Speed(MB), -time.Second, 0},
+		{"zero speed", 0, time.Hour, 0},
+		{"saturates", math.MaxUint64, time.Hour, math.MaxInt64},
+		{"largest duration", Speed(MB), maxDuration, 9223372036854775},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.speed.AmountIn(tt.dur); got != tt.want {
+				t.Errorf("AmountIn(%v) = %d, want %d", tt.dur, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSize_Over(t *testing.T) {
+	tests := []struct {
+		name string
+		size Size
+		dur  time.Duration
+		want Speed
+	}{
+		{"exact", 100 * MB, 2 * time.Second, Speed(50 * MB)},
+		{"truncates", 1, 3 * time.Second, 0},
+		{"zero duration", MB, 0, 0},
+		{"negative duration", MB, -time.Second, 0},
+		{"negative size", -MB, time.Second, 0},
+		{"saturates", math.MaxInt64, time.Nanosecond, math.MaxUint64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.size.Over(tt.dur); got != tt.want {
+				t.Errorf("Over(%v) = %d, want %d", tt.dur, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	link := Transfer{Speed: Speed(MB), Latency: 100 * time.Millisecond}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"time", link.TimeFor(10 * MB), 10100 * time.Millisecond},
+		{"time for nothing", link.TimeFor(0), 100 * time.Millisecond},
+		{"time saturates", Transfer{Latency: time.Second}.TimeFor(1), maxDuration},
+		{"amount", link.AmountIn(1100 * time.Millisecond), MB},
+		{"amount within latency", link.AmountIn(50 * time.Millisecond), Zero},
+		{"effective", link.Effective(MB), Speed(909090)},
+		{"effective without latency", Transfer{Speed: 5}.Effective(KB), Speed(5)},
+		{"effective zero speed", Transfer{}.Effective(MB), Speed(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// Unit is a single data unit, such as a kibibyte or a megabit.
+type Unit int
+
+// revive:disable exported
+
+const (
+	UnitByte Unit = iota
+	UnitKB
+	UnitMB
+	UnitGB
+	UnitTB
+	UnitPB
+	UnitEB
+	UnitKiB
+	UnitMiB
+	UnitGiB
+	UnitTiB
+	UnitPiB
+	UnitEiB
+	UnitBit
+	UnitKb
+	UnitMb
+	UnitGb
+	UnitTb
+	UnitPb
+	UnitEb
+	UnitKib
+	UnitMib
+	UnitGib
+	UnitTib
+	UnitPib
+	UnitEib
+)
+
+// revive:enable exported
+
+type unitInfo struct {
+	name   string
+	size   Size
+	family FormatUnit
+}
+
+var unitInfos = [...]unitInfo{
+	UnitByte: {"B", Byte, FormatBinaryByte},
+	UnitKB:   {"kB", KB, FormatMetricByte},
+	UnitMB:   {"MB", MB, FormatMetricByte},
+	UnitGB:   {"GB", GB, FormatMetricByte},
+	UnitTB:   {"TB", TB, FormatMetricByte},
+	UnitPB:   {"PB", PB, FormatMetricByte},
+	UnitEB:   {"EB", EB, FormatMetricByte},
+	UnitKiB:  {"kiB", KiB, FormatBinaryByte},
+	UnitMiB:  {"MiB", MiB, FormatBinaryByte},
+	UnitGiB:  {"GiB", GiB, FormatBinaryByte},
+	UnitTiB:  {"TiB", TiB, FormatBinaryByte},
+	UnitPiB:  {"PiB", PiB, FormatBinaryByte},
+	UnitEiB:  {"EiB", EiB, FormatBinaryByte},
+	UnitBit:  {"b", 0, FormatBinaryBit},
+	UnitKb:   {"Kb", Kb, FormatMetricBit},
+	UnitMb:   {"Mb", Mb, FormatMetricBit},
+	UnitGb:   {"Gb", Gb, FormatMetricBit},
+	UnitTb:   {"Tb", Tb, FormatMetricBit},
+	UnitPb:   {"Pb", Pb, FormatMetricBit},
+	UnitEb:   {"Eb", Eb, FormatMetricBit},
+	UnitKib:  {"Kib", Kib, FormatBinaryBit},
+	UnitMib:  {"Mib", Mib, FormatBinaryBit},
+	UnitGib:  {"Gib", Gib, FormatBinaryBit},
+	UnitTib:  {"Tib", Tib, FormatBinaryBit},
+	UnitPib:  {"Pib", Pib, FormatBinaryBit},
+	UnitEib:  {"Eib", Eib, FormatBinaryBit},
+}
+
+// unitNames maps every spelling accepted by Size.FormatUnitString to its Unit.
+var unitNames = map[string]Unit{
+	"B": UnitByte, "b": UnitBit,
+	"kB": UnitKB, "KB": UnitKB, "MB": UnitMB, "GB": UnitGB, "TB": UnitTB,
+	"PB": UnitPB, "EB": UnitEB,
+	"kiB": UnitKiB, "KiB": UnitKiB, "MiB": UnitMiB, "GiB": UnitGiB,
+	"TiB": UnitTiB, "PiB": UnitPiB, "EiB": UnitEiB,
+	"kb": UnitKb, "Kb": UnitKb, "Mb": UnitMb, "Gb": UnitGb, "Tb": UnitTb,
+	"Pb": UnitPb, "Eb": UnitEb,
+	"kib": UnitKib, "Kib": UnitKib, "Mib": UnitMib, "Gib": UnitGib,
+	"Tib": UnitTib, "Pib": UnitPib, "Eib": UnitEib,
+}
+
+// ParseUnit parses a unit name the same way ParseSize reads the unit of a
+// size: a lone "b" is a bit and other all-lowercase names are case-folded to
+// their byte form, so "mib" is UnitMiB while "Mib" is UnitMib.
+func ParseUnit(s string) (Unit, error) {
+	u, ok := unitNames[foldUnit(s)]
+	if !ok {
+		return 0, fmt.Errorf("invalid data unit: %q", s)
+	}
+	return u, nil
+}
+
+// foldUnit upper-cases an all-lowercase unit name other than "b", keeping the
+// "i" of binary prefixes.
+func foldUnit(name string) string {
+	// we want convert mib or tib but not weird mIb
+	if name == "b" || !all(name, unicode.IsLower) {
+		return name
+	}
+	return strings.Map(func(r rune) rune {
+		if r == 'i' {
+			return r
+		}
+		return unicode.ToUpper(r)
+	}, name)
+}
+
+func (u Unit) info() unitInfo {
+	if u < 0 || int(u) >= len(unitInfos) {
+		panic("invalid data unit")
+	}
+	return unitInfos[u]
+}
+
+// String returns the name used when formatting the unit, such as "MiB".
+func (u Unit) String() string {
+	return u.info().name
+}
+
+// Size returns the number of bytes in one unit. UnitBit, which is smaller than
+// a byte, returns zero.
+func (u Unit) Size() Size {
+	return u.info().size
+}
+
+// Family returns the family of units this unit belongs to. Bytes and bits are
+// shared by the binary and metric families and report the binary one.
+func (u Unit) Family() FormatUnit {
+	return u.info().family
+}
+
+// IsBit reports whether the unit counts bits rather than bytes.
+func (u Unit) IsBit() bool {
+	f := u.Family()
+	return f == FormatBinaryBit || f == FormatMetricBit
+}
+
+// bytes returns the exact number of bytes in one unit.
+func (u Unit) bytes() *big.Rat {
+	if u == UnitBit {
+		return big.NewRat(1, 8)
+	}
+	return new(big.Rat).SetInt64(int64(u.Size()))
+}
+
+// In returns the Size expressed in the given unit, mirroring
+// temperature.Temperature.In.
+func (d Size) In(u Unit) float64 {
+	if u == UnitBit {
+		return float64(d) * 8
+	}
+	return d.quotient(u.Size())
+}
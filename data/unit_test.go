@@ -0,0 +1,160 @@
+package data
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Unit
+		wantErr bool
+	}{
+		{"B", UnitByte, false},
+		{"b", UnitBit, false},
+		{"kB", UnitKB, false},
+		{"KB", UnitKB, false},
+		{"kb", UnitKB, false}, // lowercase input is read as bytes
+		{"Kb", UnitKb, false},
+		{"kiB", UnitKiB, false},
+		{"mib", UnitMiB, false},
+		{"Mib", UnitMib, false},
+		{"EiB", UnitEiB, false},
+		{"mIb", 0, true},
+		{"", 0, true},
+		{"XB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseUnit(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseUnit(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit(t *testing.T) {
+	tests := []struct {
+		unit   Unit
+		name   string
+		size   Size
+		family FormatUnit
+	}{
+		{UnitByte, "B", Byte, FormatBinaryByte},
+		{UnitKB, "kB", KB, FormatMetricByte},
+		{UnitKiB, "kiB", KiB, FormatBinaryByte},
+		{UnitGiB, "GiB", GiB, FormatBinaryByte},
+		{UnitBit, "b", 0, FormatBinaryBit},
+		{UnitMb, "Mb", Mb, FormatMetricBit},
+		{UnitPib, "Pib", Pib, FormatBinaryBit},
+		{UnitEib, "Eib", Eib, FormatBinaryBit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.unit.String(); got != tt.name {
+				t.Errorf("String() = %q, want %q", got, tt.name)
+			}
+			if got := tt.unit.Size(); got != tt.size {
+				t.Errorf("Size() = %d, want %d", got, tt.size)
+			}
+			if got := tt.unit.Family(); got != tt.family {
+				t.Errorf("Family() = %v, want %v", got, tt.family)
+			}
+
+			back, err := ParseUnit(tt.unit.String())
+			if err != nil || back != tt.unit {
+				t.Errorf("ParseUnit(%q) = %v, %v", tt.unit, back, err)
+			}
+		})
+	}
+}
+
+func TestUnitInvalidPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic for invalid unit")
+		}
+	}()
+
+	_ = Unit(999).String()
+}
+
+func TestSize_In(t *testing.T) {
+	tests := []struct {
+		name string
+		size Size
+		unit Unit
+		want float64
+	}{
+		{"bytes", 42, UnitByte, 42},
+		{"bits", 42, UnitBit, 336},
+		{"kibibytes", 1536, UnitKiB, 1.5},
+		{"megabits", MB, UnitMb, 8},
+		{"negative", -GiB / 2, UnitGiB, -0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.size.In(tt.unit)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSize_FormatIn(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      Size
+		unit      Unit
+		precision []int
+		want      string
+	}{
+		{"zero", 0, UnitMiB, nil, "0 MiB"},
+		{"bytes", 42, UnitByte, nil, "42 B"},
+		{"bits", 1, UnitBit, []int{1}, "8.0 b"},
+		{"binary", 1536, UnitKiB, []int{2}, "1.50 kiB"},
+		{"metric bits", KB, UnitKb, []int{2}, "8.00 Kb"},
+		{"exact", GiB + 512*MiB, UnitGiB, []int{ExactPrecision}, "1.5 GiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.size.FormatIn(tt.unit, tt.precision...)
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatUnitStringInvalidPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic for invalid unit")
+		}
+	}()
+
+	_ = KiB.FormatUnitString("XB")
+}
+
+func TestSpeed_FormatIn(t *testing.T) {
+	got := Speed(MiB+512*KiB).FormatIn(UnitMiB, 1)
+	if got != "1.5 MiB/s" {
+		t.Fatalf("got %q, want %q", got, "1.5 MiB/s")
+	}
+}
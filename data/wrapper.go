@@ -0,0 +1,168 @@
+package data
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+)
+
+// RawSize is a Size that is encoded as its raw number of bytes instead of a
+// string such as "1.5 kiB", in text and JSON, in a database and in logs.
+// Declare a field as RawSize, or convert a Size to it, to choose the number
+// form for that value only. Decoding accepts both forms like Size.
+type RawSize Size
+
+// String returns the same text as Size.String.
+func (d RawSize) String() string {
+	return Size(d).String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d RawSize) MarshalText() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(d), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler like Size.UnmarshalText.
+func (d *RawSize) UnmarshalText(text []byte) error {
+	return (*Size)(d).UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler. The size is written as a JSON number
+// of bytes.
+func (d RawSize) MarshalJSON() ([]byte, error) {
+	return d.MarshalText()
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Size.UnmarshalJSON.
+func (d *RawSize) UnmarshalJSON(data []byte) error {
+	return (*Size)(d).UnmarshalJSON(data)
+}
+
+// Value implements driver.Valuer. The size is stored as an int64 of bytes.
+func (d RawSize) Value() (driver.Value, error) {
+	return int64(d), nil
+}
+
+// Scan implements sql.Scanner like Size.Scan.
+func (d *RawSize) Scan(src any) error {
+	return (*Size)(d).Scan(src)
+}
+
+// RawSpeed is a Speed that is encoded as its raw number of bytes per second
+// instead of a string such as "1.5 kiB/s", like RawSize.
+type RawSpeed Speed
+
+// String returns the same text as Speed.String.
+func (s RawSpeed) String() string {
+	return Speed(s).String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s RawSpeed) MarshalText() ([]byte, error) {
+	return strconv.AppendUint(nil, uint64(s), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler like Speed.UnmarshalText.
+func (s *RawSpeed) UnmarshalText(text []byte) error {
+	return (*Speed)(s).UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler. The speed is written as a JSON number
+// of bytes per second.
+func (s RawSpeed) MarshalJSON() ([]byte, error) {
+	return s.MarshalText()
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Speed.UnmarshalJSON.
+func (s *RawSpeed) UnmarshalJSON(data []byte) error {
+	return (*Speed)(s).UnmarshalJSON(data)
+}
+
+// Value implements driver.Valuer like Speed.Value.
+func (s RawSpeed) Value() (driver.Value, error) {
+	return Speed(s).Value()
+}
+
+// Scan implements sql.Scanner like Speed.Scan.
+func (s *RawSpeed) Scan(src any) error {
+	return (*Speed)(s).Scan(src)
+}
+
+// HumanSize is a Size that is encoded as a string such as "1.5 kiB"
+// everywhere: losslessly in text, JSON and a database, where a Size is stored
+// as a number, and as String in logs. Decoding accepts both forms like Size.
+type HumanSize Size
+
+// String returns the same text as Size.String.
+func (d HumanSize) String() string {
+	return Size(d).String()
+}
+
+// MarshalText implements encoding.TextMarshaler like Size.MarshalText.
+func (d HumanSize) MarshalText() ([]byte, error) {
+	return Size(d).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler like Size.UnmarshalText.
+func (d *HumanSize) UnmarshalText(text []byte) error {
+	return (*Size)(d).UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler like Size.MarshalJSON.
+func (d HumanSize) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Size(d).Exact())
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Size.UnmarshalJSON.
+func (d *HumanSize) UnmarshalJSON(data []byte) error {
+	return (*Size)(d).UnmarshalJSON(data)
+}
+
+// Value implements driver.Valuer. The size is stored as the string of Exact.
+func (d HumanSize) Value() (driver.Value, error) {
+	return Size(d).Exact(), nil
+}
+
+// Scan implements sql.Scanner like Size.Scan.
+func (d *HumanSize) Scan(src any) error {
+	return (*Size)(d).Scan(src)
+}
+
+// HumanSpeed is a Speed that is encoded as a lossless string such as
+// "1.5 kiB/s" everywhere, like HumanSize.
+type HumanSpeed Speed
+
+// String returns the same text as Speed.String.
+func (s HumanSpeed) String() string {
+	return Speed(s).String()
+}
+
+// MarshalText implements encoding.TextMarshaler like Speed.MarshalText.
+func (s HumanSpeed) MarshalText() ([]byte, error) {
+	return Speed(s).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler like Speed.UnmarshalText.
+func (s *HumanSpeed) UnmarshalText(text []byte) error {
+	return (*Speed)(s).UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler like Speed.MarshalJSON.
+func (s HumanSpeed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Speed(s).Exact())
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Speed.UnmarshalJSON.
+func (s *HumanSpeed) UnmarshalJSON(data []byte) error {
+	return (*Speed)(s).UnmarshalJSON(data)
+}
+
+// Value implements driver.Valuer. The speed is stored as the string of Exact.
+func (s HumanSpeed) Value() (driver.Value, error) {
+	return Speed(s).Exact(), nil
+}
+
+// Scan implements sql.Scanner like Speed.Scan.
+func (s *HumanSpeed) Scan(src any) error {
+	return (*Speed)(s).Scan(src)
+}
@@ -0,0 +1,166 @@
+// Package httpshape shapes and accounts for the bandwidth of HTTP bodies
+// with the limiters of package data.
+//
+// Middleware caps the response bodies sent to each client, and Transport
+// throttles the request and response bodies of an http.Client. Both report
+// the size and speed of every body through a callback.
+package httpshape
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Nadim147c/real-go/data"
+)
+
+// Stats describes the bodies of one request once it has completed.
+type Stats struct {
+	// Key is the client key of the request in Middleware, and the host of
+	// the request URL in Transport.
+	Key string
+	// Request is the request, which must not be modified.
+	Request *http.Request
+	// RequestSize and ResponseSize are the body bytes read and written.
+	RequestSize, ResponseSize data.Size
+	// Duration is the time from the start of the request until its response
+	// body was done.
+	Duration time.Duration
+}
+
+// RequestSpeed returns the average speed of the request body.
+func (s Stats) RequestSpeed() data.Speed {
+	return s.RequestSize.Over(s.Duration)
+}
+
+// ResponseSpeed returns the average speed of the response body.
+func (s Stats) ResponseSpeed() data.Speed {
+	return s.ResponseSize.Over(s.Duration)
+}
+
+// KeyFunc returns the key that identifies the client of a request. Requests
+// with the same key share one limit.
+type KeyFunc func(*http.Request) string
+
+// RemoteIP is the default KeyFunc. It returns the IP address of the client
+// without the port.
+func RemoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Option configures Middleware and Transport.
+type Option func(*options)
+
+type options struct {
+	key   KeyFunc
+	stats func(Stats)
+	clock data.Clock
+	burst data.Size
+}
+
+// WithKey sets the KeyFunc of Middleware. The default is RemoteIP.
+func WithKey(key KeyFunc) Option {
+	return func(o *options) { o.key = key }
+}
+
+// WithStats sets a callback that receives the Stats of every request. It
+// may be called concurrently.
+func WithStats(stats func(Stats)) Option {
+	return func(o *options) { o.stats = stats }
+}
+
+// WithClock sets the Clock used for limits and durations.
+func WithClock(c data.Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+// WithBurst sets the burst of the limiters, see data.WithBurst.
+func WithBurst(burst data.Size) Option {
+	return func(o *options) { o.burst = burst }
+}
+
+func newOptions(opts []Option) options {
+	o := options{key: RemoteIP, stats: func(Stats) {}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// dataOptions returns the options of the data limiters.
+func (o options) dataOptions() []data.Option {
+	opts := []data.Option{data.WithBurst(o.burst)}
+	if o.clock != nil {
+		opts = append(opts, data.WithClock(o.clock))
+	}
+	return opts
+}
+
+func (o options) now() time.Time {
+	if o.clock == nil {
+		return time.Now()
+	}
+	return o.clock.Now()
+}
+
+// clients holds a Bandwidth per client key while the client has requests in
+// flight.
+type clients struct {
+	mu    sync.Mutex
+	limit data.Speed
+	opts  []data.Option
+	m     map[string]*client
+}
+
+type client struct {
+	bandwidth *data.Bandwidth
+	requests  int
+}
+
+// open opens a stream for a request of the client, and returns it with a
+// function that closes it.
+func (c *clients) open(key string) (*data.Stream, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl, ok := c.m[key]
+	if !ok {
+		cl = &client{bandwidth: data.NewBandwidth(c.limit, c.opts...)}
+		c.m[key] = cl
+	}
+	cl.requests++
+	stream := cl.bandwidth.NewStream(1, 0)
+
+	return stream, func() {
+		stream.Close()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if cl.requests--; cl.requests == 0 {
+			delete(c.m, key)
+		}
+	}
+}
+
+// countingReader counts the bytes read through it. The count may be read
+// while another goroutine reads.
+type countingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
+
+// Size returns the number of bytes read so far.
+func (r *countingReader) Size() data.Size {
+	return data.Size(r.n.Load())
+}
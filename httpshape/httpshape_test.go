@@ -0,0 +1,87 @@
+package httpshape
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/real-go/data"
+)
+
+// fakeClock is a data.Clock whose After advances the time and fires at once.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(max(d, 0))
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestRemoteIP(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{"192.0.2.1:1234", "192.0.2.1"},
+		{"[2001:db8::1]:80", "2001:db8::1"},
+		{"pipe", "pipe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: tt.addr}
+			if got := RemoteIP(r); got != tt.want {
+				t.Errorf("RemoteIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStats_Speed(t *testing.T) {
+	s := Stats{
+		RequestSize:  data.KB,
+		ResponseSize: data.MB,
+		Duration:     2 * time.Second,
+	}
+	if got := s.RequestSpeed(); got != 500 {
+		t.Errorf("RequestSpeed() = %v, want 500 B/s", got)
+	}
+	if got := s.ResponseSpeed(); got != data.Speed(500*data.KB) {
+		t.Errorf("ResponseSpeed() = %v, want 500 kB/s", got)
+	}
+}
+
+func TestClients(t *testing.T) {
+	cs := &clients{limit: 1000, m: make(map[string]*client)}
+	a1, doneA1 := cs.open("a")
+	a2, doneA2 := cs.open("a")
+	b, doneB := cs.open("b")
+
+	if a1.Speed() != 500 || a2.Speed() != 500 || b.Speed() != 1000 {
+		t.Fatalf("shares = %v, %v, %v, want 500, 500, 1000",
+			a1.Speed(), a2.Speed(), b.Speed())
+	}
+
+	doneA1()
+	if a2.Speed() != 1000 {
+		t.Fatalf("share after close = %v, want 1000", a2.Speed())
+	}
+	doneA2()
+	doneB()
+	if len(cs.m) != 0 {
+		t.Fatalf("%d clients left", len(cs.m))
+	}
+}
@@ -0,0 +1,79 @@
+package httpshape
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/Nadim147c/real-go/data"
+)
+
+// Middleware returns middleware that caps the response bodies sent to each
+// client at limit. Concurrent requests of the same client share the limit
+// fairly. A limit of zero only measures.
+//
+// Writes wait for the limit within the context of the request, so they stop
+// when the client goes away.
+func Middleware(
+	limit data.Speed, opts ...Option,
+) func(http.Handler) http.Handler {
+	o := newOptions(opts)
+	cs := &clients{
+		limit: limit,
+		opts:  o.dataOptions(),
+		m:     make(map[string]*client),
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := o.key(r)
+			stream, done := cs.open(key)
+			defer done()
+
+			start := o.now()
+			body := &countingReader{r: r.Body}
+			if r.Body != nil {
+				r.Body = struct {
+					io.Reader
+					io.Closer
+				}{body, r.Body}
+			}
+			sw := &shapedWriter{
+				ResponseWriter: w,
+				w:              stream.Writer(w, data.WithContext(r.Context())),
+			}
+
+			next.ServeHTTP(sw, r)
+
+			o.stats(Stats{
+				Key:          key,
+				Request:      r,
+				RequestSize:  body.Size(),
+				ResponseSize: sw.n,
+				Duration:     o.now().Sub(start),
+			})
+		})
+	}
+}
+
+// shapedWriter is an http.ResponseWriter whose body goes through a limited
+// writer. Flush passes through so that streaming handlers keep working, and
+// Unwrap lets http.ResponseController reach the original.
+type shapedWriter struct {
+	http.ResponseWriter
+	w io.Writer
+	n data.Size
+}
+
+func (w *shapedWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += data.Size(n)
+	return n, err
+}
+
+func (w *shapedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *shapedWriter) Flush() {
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
@@ -0,0 +1,137 @@
+package httpshape
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/real-go/data"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit data.Speed
+		body  string
+		key   KeyFunc
+		want  Stats
+	}{
+		{
+			name:  "limited",
+			limit: 1000,
+			body:  "hello",
+			want: Stats{
+				Key:          "192.0.2.1",
+				RequestSize:  5,
+				ResponseSize: 3000,
+				Duration:     2 * time.Second,
+			},
+		},
+		{
+			name: "measure only",
+			key:  func(r *http.Request) string { return r.Header.Get("Token") },
+			want: Stats{Key: "secret", ResponseSize: 3000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Stats
+			opts := []Option{
+				WithClock(&fakeClock{}),
+				WithStats(func(s Stats) { got = s }),
+			}
+			if tt.key != nil {
+				opts = append(opts, WithKey(tt.key))
+			}
+			handler := Middleware(tt.limit, opts...)(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					io.Copy(io.Discard, r.Body)
+					w.Write(bytes.Repeat([]byte("x"), 3000))
+				},
+			))
+
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			req.RemoteAddr = "192.0.2.1:1234"
+			req.Header.Set("Token", "secret")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Body.Len() != 3000 {
+				t.Fatalf("response has %d bytes, want 3000", rec.Body.Len())
+			}
+			if got.Request != req {
+				t.Fatal("stats of another request")
+			}
+			got.Request = nil
+			if got != tt.want {
+				t.Fatalf("stats = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware_ResponseController(t *testing.T) {
+	handler := Middleware(0)(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte("x"))
+			if err := http.NewResponseController(w).Flush(); err != nil {
+				t.Errorf("Flush() failed: %v", err)
+			}
+		},
+	))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if !rec.Flushed {
+		t.Fatal("response not flushed")
+	}
+}
+
+func TestMiddleware_Flusher(t *testing.T) {
+	handler := Middleware(1000)(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			f, ok := w.(http.Flusher)
+			if !ok {
+				t.Fatal("writer is not an http.Flusher")
+			}
+			w.Write([]byte("x"))
+			f.Flush()
+		},
+	))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if !rec.Flushed {
+		t.Fatal("response not flushed")
+	}
+}
+
+// countingWriter is an http.ResponseWriter that counts the writes reaching it.
+type countingWriter struct {
+	http.ResponseWriter
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.ResponseWriter.Write(p)
+}
+
+func TestMiddleware_Unlimited(t *testing.T) {
+	handler := Middleware(0)(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.Write(bytes.Repeat([]byte("x"), 3000))
+		},
+	))
+
+	w := &countingWriter{ResponseWriter: httptest.NewRecorder()}
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.writes != 1 {
+		t.Fatalf("made %d writes, want 1", w.writes)
+	}
+}
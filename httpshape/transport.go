@@ -0,0 +1,130 @@
+package httpshape
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Nadim147c/real-go/data"
+)
+
+// Transport is an http.RoundTripper that throttles and measures the bodies of
+// the requests it sends and the responses it receives.
+type Transport struct {
+	base http.RoundTripper
+	o    options
+	up   *data.Bandwidth
+	down *data.Bandwidth
+}
+
+// NewTransport returns a Transport that sends request bodies at no more than
+// up and receives response bodies at no more than down, shared by all the
+// requests in flight. A limit of zero only measures. A nil base uses
+// http.DefaultTransport. WithKey does not apply.
+func NewTransport(
+	base http.RoundTripper, up, down data.Speed, opts ...Option,
+) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	o := newOptions(opts)
+	return &Transport{
+		base: base,
+		o:    o,
+		up:   data.NewBandwidth(up, o.dataOptions()...),
+		down: data.NewBandwidth(down, o.dataOptions()...),
+	}
+}
+
+// SetLimits changes the limits of the requests in flight and to come.
+func (t *Transport) SetLimits(up, down data.Speed) {
+	t.up.SetLimit(up)
+	t.down.SetLimit(down)
+}
+
+// RoundTrip implements http.RoundTripper. The Stats of the request are
+// reported once the response body has been read to the end or closed, or
+// when the round trip fails.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := t.o.now()
+	upStream := t.up.NewStream(1, 0)
+	downStream := t.down.NewStream(1, 0)
+	ctx := data.WithContext(req.Context())
+
+	tr := &tracked{
+		t:     t,
+		stats: Stats{Key: req.URL.Host, Request: req},
+		start: start,
+		close: func() {
+			upStream.Close()
+			downStream.Close()
+		},
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body := &countingReader{r: upStream.Reader(req.Body, ctx)}
+		tr.reqBody = body
+		req = req.Clone(req.Context())
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{body, req.Body}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		tr.finish()
+		return nil, err
+	}
+
+	tr.respBody = &countingReader{r: downStream.Reader(resp.Body, ctx)}
+	resp.Body = &trackedBody{tr: tr, body: resp.Body}
+	return resp, nil
+}
+
+// tracked is the accounting of one round trip.
+type tracked struct {
+	t        *Transport
+	stats    Stats
+	start    time.Time
+	reqBody  *countingReader
+	respBody *countingReader
+	close    func()
+	once     sync.Once
+}
+
+func (tr *tracked) finish() {
+	tr.once.Do(func() {
+		tr.close()
+		if tr.reqBody != nil {
+			tr.stats.RequestSize = tr.reqBody.Size()
+		}
+		if tr.respBody != nil {
+			tr.stats.ResponseSize = tr.respBody.Size()
+		}
+		tr.stats.Duration = tr.t.o.now().Sub(tr.start)
+		tr.t.o.stats(tr.stats)
+	})
+}
+
+// trackedBody is a response body that reads through the limiter and finishes
+// its round trip at EOF or Close.
+type trackedBody struct {
+	tr   *tracked
+	body io.ReadCloser
+}
+
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.tr.respBody.Read(p)
+	if err == io.EOF {
+		b.tr.finish()
+	}
+	return n, err
+}
+
+func (b *trackedBody) Close() error {
+	err := b.body.Close()
+	b.tr.finish()
+	return err
+}
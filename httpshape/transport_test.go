@@ -0,0 +1,107 @@
+package httpshape
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(io.Discard, r.Body)
+			w.Write(bytes.Repeat([]byte("x"), 2000))
+		},
+	))
+	defer srv.Close()
+
+	stats := make(chan Stats, 1)
+	transport := NewTransport(srv.Client().Transport, 100, 1000,
+		WithClock(&fakeClock{}),
+		WithStats(func(s Stats) { stats <- s }),
+	)
+	client := &http.Client{Transport: transport}
+
+	body := strings.Repeat("y", 300)
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || len(got) != 2000 {
+		t.Fatalf("read %d bytes, %v", len(got), err)
+	}
+
+	s := <-stats
+	if s.Key != strings.TrimPrefix(srv.URL, "http://") {
+		t.Errorf("Key = %q", s.Key)
+	}
+	if s.RequestSize != 300 || s.ResponseSize != 2000 {
+		t.Errorf("sizes = %d, %d, want 300, 2000", s.RequestSize, s.ResponseSize)
+	}
+	// 200 bytes over the burst at 100 B/s, 1000 bytes at 1000 B/s.
+	if s.Duration != 3*time.Second {
+		t.Errorf("Duration = %v, want 3s", s.Duration)
+	}
+}
+
+func TestTransport_Error(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	var calls int
+	transport := NewTransport(nil, 0, 0, WithStats(func(Stats) { calls++ }))
+	_, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("stats reported %d times, want once", calls)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper backed by a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// countingBody is a response body that counts the reads reaching it.
+type countingBody struct {
+	io.Reader
+	reads int
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	b.reads++
+	return b.Reader.Read(p)
+}
+
+func (b *countingBody) Close() error { return nil }
+
+func TestTransport_Unlimited(t *testing.T) {
+	body := &countingBody{Reader: bytes.NewReader(make([]byte, 2000))}
+	transport := NewTransport(roundTripFunc(
+		func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: body, Request: r}, nil
+		},
+	), 0, 0)
+
+	resp, err := (&http.Client{Transport: transport}).Get("http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if n, err := resp.Body.Read(make([]byte, 2000)); n != 2000 {
+		t.Fatalf("Read() = %d, %v, want 2000", n, err)
+	}
+	if body.reads != 1 {
+		t.Fatalf("made %d reads, want 1", body.reads)
+	}
+}
@@ -0,0 +1,41 @@
+// Package format applies the flags and width of a fmt.State to the text of a
+// value with a unit, shared by the Format methods of this module.
+package format
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Write writes text, the formatted value and its unit, to f as a whole:
+//   - the '+' flag puts a plus sign before non-negative values, and the ' '
+//     flag a space
+//   - the verb %q quotes the text
+//   - the width pads the text with spaces on the left, or on the right with
+//     the '-' flag
+func Write(f fmt.State, verb rune, text string, nonNegative bool) {
+	if nonNegative {
+		switch {
+		case f.Flag('+'):
+			text = "+" + text
+		case f.Flag(' '):
+			text = " " + text
+		}
+	}
+	if verb == 'q' {
+		text = strconv.Quote(text)
+	}
+
+	width, ok := f.Width()
+	if pad := width - utf8.RuneCountInString(text); ok && pad > 0 {
+		if f.Flag('-') {
+			text += strings.Repeat(" ", pad)
+		} else {
+			text = strings.Repeat(" ", pad) + text
+		}
+	}
+	io.WriteString(f, text)
+}
@@ -0,0 +1,234 @@
+// Package progress renders progress bars for transfers measured in data.Size
+// and data.Speed.
+//
+// On a terminal, a Renderer redraws one line per transfer in place:
+//
+//	db.tar [=====>    ]  50% 1.50 GiB / 3.00 GiB 10.00 MiB/s ETA 2m30s
+//
+// When the output is not a terminal, such as a log file or a pipe, it writes
+// plain lines at most once per Interval instead, and once more when every
+// transfer is done.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Nadim147c/real-go/data"
+)
+
+// State is the progress of one transfer.
+type State struct {
+	// Name labels the line. It may be empty.
+	Name string
+	// Done is the amount transferred so far.
+	Done data.Size
+	// Total is the amount expected. Zero means unknown, which hides the bar
+	// and the percentage.
+	Total data.Size
+	// Speed is the current speed. Zero hides it.
+	Speed data.Speed
+	// ETA is the estimated time left. Zero or less hides it.
+	ETA time.Duration
+}
+
+// finished reports whether the transfer has reached its known total.
+func (s State) finished() bool {
+	return s.Total > 0 && s.Done >= s.Total
+}
+
+// Renderer draws progress to an io.Writer. Change its fields before the first
+// call to Render. A Renderer is not safe for concurrent use.
+type Renderer struct {
+	// TTY selects redrawing in place. New sets it when the writer is a
+	// terminal.
+	TTY bool
+	// Verb formats sizes and speeds: 'B' for binary units or 'M' for metric
+	// units, as in data.Size.Format.
+	Verb rune
+	// Precision is the number of decimal places, or -1 for the default of
+	// data.Size.Format.
+	Precision int
+	// Width is the width of the bar, without its brackets.
+	Width int
+	// Interval is the shortest time between plain lines when not on a
+	// terminal.
+	Interval time.Duration
+	// Clock tells time for Interval. Nil means the system clock.
+	Clock data.Clock
+
+	w       io.Writer
+	lines   int       // lines drawn by the last render on a terminal
+	logged  time.Time // time of the last plain lines
+	started bool      // plain lines have been written
+	done    bool      // every transfer was finished at the last render
+	current bool      // the last render wrote plain lines
+}
+
+// New returns a Renderer writing to w, with binary units, a 30 column bar and
+// plain lines every 10 seconds.
+func New(w io.Writer) *Renderer {
+	return &Renderer{
+		TTY:       isTerminal(w),
+		Verb:      'B',
+		Precision: -1,
+		Width:     30,
+		Interval:  10 * time.Second,
+		w:         w,
+	}
+}
+
+// isTerminal reports whether w is a character device, such as a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Render draws the states, one line each. On a terminal it replaces the lines
+// drawn by the previous call. Otherwise it writes them only if Interval has
+// passed since the last time, or if every transfer has just finished.
+func (r *Renderer) Render(states ...State) error {
+	if r.TTY {
+		return r.redraw(states)
+	}
+
+	now := r.now()
+	done := allFinished(states)
+	justDone := done && !r.done
+	r.done = done
+	r.current = !r.started || justDone || now.Sub(r.logged) >= r.Interval
+	if !r.current {
+		return nil
+	}
+	r.started = true
+	r.logged = now
+	return r.log(states)
+}
+
+// Finish draws the final states regardless of Interval, unless the last
+// Render already wrote them as plain lines because they were all finished. On
+// a terminal the lines are left in place, and the next Render starts below
+// them.
+func (r *Renderer) Finish(states ...State) error {
+	var err error
+	switch {
+	case r.TTY:
+		err = r.redraw(states)
+	case !(r.current && r.done):
+		err = r.log(states)
+	}
+	r.lines, r.started, r.done, r.current = 0, false, false, false
+	return err
+}
+
+func (r *Renderer) redraw(states []State) error {
+	var b strings.Builder
+	if r.lines > 0 {
+		// Move to the start of the first line drawn last time.
+		fmt.Fprintf(&b, "\x1b[%dF", r.lines)
+	}
+	for _, s := range states {
+		b.WriteString("\x1b[2K")
+		b.WriteString(r.Line(s, true))
+		b.WriteByte('\n')
+	}
+	// Clear lines left over from a longer previous render.
+	for i := len(states); i < r.lines; i++ {
+		b.WriteString("\x1b[2K\n")
+	}
+	if extra := r.lines - len(states); extra > 0 {
+		fmt.Fprintf(&b, "\x1b[%dF", extra)
+	}
+	r.lines = len(states)
+	_, err := io.WriteString(r.w, b.String())
+	return err
+}
+
+func (r *Renderer) log(states []State) error {
+	var b strings.Builder
+	for _, s := range states {
+		b.WriteString(r.Line(s, false))
+		b.WriteByte('\n')
+	}
+	_, err := io.WriteString(r.w, b.String())
+	return err
+}
+
+// Line formats a single state, with a bar if requested and the total is known.
+func (r *Renderer) Line(s State, bar bool) string {
+	var parts []string
+	if s.Name != "" {
+		parts = append(parts, s.Name)
+	}
+
+	if s.Total > 0 {
+		fraction := min(max(float64(s.Done)/float64(s.Total), 0), 1)
+		if bar {
+			parts = append(parts, r.bar(fraction))
+		}
+		parts = append(parts,
+			fmt.Sprintf("%3.0f%%", fraction*100),
+			r.format(s.Done)+" / "+r.format(s.Total))
+	} else {
+		parts = append(parts, r.format(s.Done))
+	}
+
+	if s.Speed > 0 {
+		parts = append(parts, r.format(s.Speed))
+	}
+	if s.ETA > 0 && !s.finished() {
+		parts = append(parts, "ETA "+s.ETA.Round(time.Second).String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// bar draws a bar filled to fraction, such as "[=====>    ]".
+func (r *Renderer) bar(fraction float64) string {
+	width := max(r.Width, 1)
+	filled := int(fraction * float64(width))
+
+	var b strings.Builder
+	b.WriteByte('[')
+	b.WriteString(strings.Repeat("=", filled))
+	if filled < width {
+		b.WriteByte('>')
+		b.WriteString(strings.Repeat(" ", width-filled-1))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// format formats a data.Size or data.Speed with Verb and Precision.
+func (r *Renderer) format(v fmt.Formatter) string {
+	verb := r.Verb
+	if verb != 'M' {
+		verb = 'B'
+	}
+	if r.Precision < 0 {
+		return fmt.Sprintf("%"+string(verb), v)
+	}
+	return fmt.Sprintf("%.*"+string(verb), r.Precision, v)
+}
+
+func (r *Renderer) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+func allFinished(states []State) bool {
+	for _, s := range states {
+		if !s.finished() {
+			return false
+		}
+	}
+	return len(states) > 0
+}
@@ -0,0 +1,150 @@
+package progress
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/real-go/data"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestRenderer_Line(t *testing.T) {
+	half := State{
+		Name:  "db.tar",
+		Done:  1536 * data.MiB,
+		Total: 3 * data.GiB,
+		Speed: data.Speed(10 * data.MiB),
+		ETA:   150*time.Second + 300*time.Millisecond,
+	}
+
+	tests := []struct {
+		name      string
+		state     State
+		bar       bool
+		verb      rune
+		precision int
+		want      string
+	}{
+		{
+			"bar", half, true, 'B', -1,
+			"db.tar [=====>    ]  50% 1.50 GiB / 3.00 GiB 10.00 MiB/s ETA 2m30s",
+		},
+		{
+			"plain", half, false, 'B', -1,
+			"db.tar  50% 1.50 GiB / 3.00 GiB 10.00 MiB/s ETA 2m30s",
+		},
+		{
+			"metric", half, false, 'M', 1,
+			"db.tar  50% 1.6 GB / 3.2 GB 10.5 MB/s ETA 2m30s",
+		},
+		{
+			"unknown total", State{Done: 5 * data.MB}, true, 'M', -1,
+			"5.00 MB",
+		},
+		{
+			"done",
+			State{Name: "x", Done: data.KB, Total: data.KB, ETA: time.Second},
+			true, 'M', 0,
+			"x [==========] 100% 1 kB / 1 kB",
+		},
+		{
+			"empty", State{Total: data.KB}, true, 'M', -1,
+			"[>         ]   0% 0 B / 1.00 kB",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(nil)
+			r.Width = 10
+			r.Verb = tt.verb
+			r.Precision = tt.precision
+			if got := r.Line(tt.state, tt.bar); got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_Plain(t *testing.T) {
+	var out strings.Builder
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	r := New(&out)
+	r.Clock = clock
+	r.Interval = 10 * time.Second
+
+	state := State{Name: "a", Total: 100}
+	for range 25 {
+		state.Done += 4
+		r.Render(state)
+		clock.After(time.Second)
+	}
+	r.Finish(state)
+
+	want := strings.Join([]string{
+		"a   4% 4 B / 100 B",
+		"a  44% 44 B / 100 B",
+		"a  84% 84 B / 100 B",
+		"a 100% 100 B / 100 B",
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Fatalf("got\n%s\nwant\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	r.Render(State{Name: "b"})
+	r.Finish(State{Name: "b", Done: 1})
+	if want := "b 0 B\nb 1 B\n"; out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestRenderer_TTY(t *testing.T) {
+	var out strings.Builder
+	r := New(&out)
+	r.TTY = true
+	r.Width = 4
+
+	r.Render(State{Name: "a", Total: 4}, State{Name: "b"})
+	r.Render(State{Name: "a", Done: 2, Total: 4})
+	r.Finish(State{Name: "a", Done: 4, Total: 4})
+	r.Render(State{Name: "c"})
+
+	want := "" +
+		"\x1b[2Ka [>   ]   0% 0 B / 4 B\n" +
+		"\x1b[2Kb 0 B\n" +
+		"\x1b[2F" +
+		"\x1b[2Ka [==> ]  50% 2 B / 4 B\n" +
+		"\x1b[2K\n" +
+		"\x1b[1F" +
+		"\x1b[1F" +
+		"\x1b[2Ka [====] 100% 4 B / 4 B\n" +
+		"\x1b[2Kc 0 B\n"
+	if out.String() != want {
+		t.Fatalf("got  %q\nwant %q", out.String(), want)
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "progress")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if isTerminal(f) || isTerminal(&strings.Builder{}) {
+		t.Fatal("regular file or buffer detected as a terminal")
+	}
+}
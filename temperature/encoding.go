@@ -0,0 +1,69 @@
+package temperature
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler. The value is written in
+// celsius, such as "20 °C". Use Human for another unit.
+func (t Temperature) MarshalText() ([]byte, error) {
+	return marshalIn(t, UnitCelsius), nil
+}
+
+// marshalIn returns t as text in u, rounded to nine decimal places to hide
+// floating-point noise from unit conversion.
+func marshalIn(t Temperature, u Unit) []byte {
+	value := strconv.FormatFloat(t.In(u), 'f', 9, 64)
+	value = strings.TrimRight(value, "0")
+	value = strings.TrimSuffix(value, ".")
+	return []byte(value + " " + u.symbol())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using Parse.
+func (t *Temperature) UnmarshalText(text []byte) error {
+	temp, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*t = temp
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The Temperature is written as a JSON
+// string like MarshalText.
+func (t Temperature) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON string
+// understood by Parse or a JSON number in kelvin. A JSON null leaves the
+// Temperature unchanged.
+func (t *Temperature) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		return t.UnmarshalText([]byte(str))
+	}
+
+	kelvin, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("expected JSON string or number, got %s", data)
+	}
+	*t = Kelvin(kelvin)
+	return nil
+}
@@ -0,0 +1,139 @@
+package temperature
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		unit Unit
+		t    Temperature
+		want string
+	}{
+		{"celsius", UnitCelsius, Celsius(20), `"20 °C"`},
+		{"celsius fraction", UnitCelsius, Celsius(37.5), `"37.5 °C"`},
+		{"fahrenheit", UnitFahrenheit, Celsius(20), `"68 °F"`},
+		{"fahrenheit negative", UnitFahrenheit, Fahrenheit(-40), `"-40 °F"`},
+		{"kelvin", UnitKelvin, Freezing, `"273.15 K"`},
+		{"absolute zero", UnitCelsius, AbsoluteZero, `"-273.15 °C"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(Human{tt.t, tt.unit})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+
+			back := Human{Unit: tt.unit}
+			if err := json.Unmarshal(got, &back); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(float64(back.Temperature-tt.t)) > 1e-9 {
+				t.Fatalf("round trip got %v, want %v", back.Temperature, tt.t)
+			}
+			if back.Unit != tt.unit {
+				t.Fatalf("round trip changed the unit to %d", back.Unit)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Temperature
+		wantErr bool
+	}{
+		{"string", `"68F"`, Fahrenheit(68), false},
+		{"number is kelvin", `300`, Kelvin(300), false},
+		{"null keeps value", `null`, Boiling, false},
+		{"bad string", `"20"`, 0, true},
+		{"bool", `false`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Boiling
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(float64(got-tt.want)) > 1e-9 {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextMarshaler(t *testing.T) {
+	config := struct {
+		Alarm Temperature `json:"alarm"`
+		Limit Human       `json:"limit"`
+	}{Limit: Human{Unit: UnitKelvin}}
+	in := `{"alarm":"80 °C","limit":"212F"}`
+	if err := json.Unmarshal([]byte(in), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(float64(config.Alarm-Celsius(80))) > 1e-9 {
+		t.Fatalf("got %v, want %v", config.Alarm, Celsius(80))
+	}
+
+	// Each field keeps its own unit.
+	out, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"alarm":"80 °C","limit":"373.15 K"}`; string(out) != want {
+		t.Fatalf("got %s, want %s", out, want)
+	}
+
+	text, err := Human{Freezing, UnitFahrenheit}.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(text) != "32 °F" {
+		t.Fatalf("got %q, want %q", text, "32 °F")
+	}
+}
+
+func TestRaw(t *testing.T) {
+	var config struct {
+		Alarm Raw `json:"alarm"`
+	}
+	if err := json.Unmarshal([]byte(`{"alarm":"100 °C"}`), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(float64(config.Alarm)-float64(Boiling)) > 1e-9 {
+		t.Fatalf("got %v, want %v", config.Alarm, Boiling)
+	}
+
+	out, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"alarm":373.15}`; string(out) != want {
+		t.Fatalf("got %s, want %s", out, want)
+	}
+
+	var back Raw
+	if err := back.UnmarshalText([]byte("373.15")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if back != config.Alarm {
+		t.Fatalf("text round trip got %v, want %v", back, config.Alarm)
+	}
+}
@@ -0,0 +1,35 @@
+package temperature
+
+import "flag"
+
+// Set implements flag.Value using Parse.
+func (t *Temperature) Set(s string) error {
+	return t.UnmarshalText([]byte(s))
+}
+
+// Var defines a Temperature flag with the specified name, default value, and
+// usage string on fs, such as --alarm=80C. The argument p points to a
+// Temperature variable in which to store the value of the flag. A nil fs
+// defines the flag on flag.CommandLine.
+func Var(
+	fs *flag.FlagSet, p *Temperature, name string, def Temperature,
+	usage string,
+) {
+	*p = def
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.Var(p, name, usage)
+}
+
+// Flag defines a Temperature flag with the specified name, default value, and
+// usage string on fs. The return value is the address of a Temperature
+// variable that stores the value of the flag. A nil fs defines the flag on
+// flag.CommandLine.
+func Flag(
+	fs *flag.FlagSet, name string, def Temperature, usage string,
+) *Temperature {
+	p := new(Temperature)
+	Var(fs, p, name, def, usage)
+	return p
+}
@@ -0,0 +1,58 @@
+package temperature
+
+import (
+	"bytes"
+	"flag"
+	"math"
+	"testing"
+)
+
+func TestVar(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    Temperature
+		wantErr bool
+	}{
+		{"default", nil, Boiling, false},
+		{"celsius", []string{"--alarm=80C"}, Celsius(80), false},
+		{"fahrenheit", []string{"-alarm", "-40 °F"}, Fahrenheit(-40), false},
+		{"missing unit", []string{"--alarm=80"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+
+			var got Temperature
+			Var(fs, &got, "alarm", Boiling, "alarm threshold")
+
+			err := fs.Parse(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(float64(got-tt.want)) > 1e-9 {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	got := Flag(fs, "alarm", Freezing, "alarm threshold")
+
+	if err := fs.Parse([]string{"--alarm=300K"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *got != Kelvin(300) {
+		t.Fatalf("got %v, want %v", *got, Kelvin(300))
+	}
+}
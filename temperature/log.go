@@ -0,0 +1,28 @@
+package temperature
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer. The Temperature is logged as a group
+// with a "kelvin" and a "human" attribute. Log a Raw or a Human for only one of
+// them.
+func (t Temperature) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Float64("kelvin", float64(t)),
+		slog.String("human", t.String()),
+	)
+}
+
+// LogValue implements slog.LogValuer with the temperature in kelvin.
+func (r Raw) LogValue() slog.Value {
+	return slog.Float64Value(float64(r))
+}
+
+// LogValue implements slog.LogValuer with the temperature in Unit, to two
+// decimal places, such as "68.00 °F".
+func (h Human) LogValue() slog.Value {
+	value := strconv.FormatFloat(h.In(h.Unit), 'f', 2, 64)
+	return slog.StringValue(value + " " + h.Unit.symbol())
+}
@@ -0,0 +1,43 @@
+package temperature
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"both", Freezing, `v.kelvin=273.15 v.human="0.00 °C"`},
+		{"raw", Raw(Freezing), `v=273.15`},
+		{"human", Human{Freezing, UnitCelsius}, `v="0.00 °C"`},
+		{"human fahrenheit", Human{Boiling, UnitFahrenheit}, `v="212.00 °F"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			var buf bytes.Buffer
+			handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					switch a.Key {
+					case slog.TimeKey, slog.LevelKey, slog.MessageKey:
+						return slog.Attr{}
+					}
+					return a
+				},
+			})
+			slog.New(handler).Info("m", "v", tt.v)
+
+			got := strings.TrimSpace(buf.String())
+			if got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
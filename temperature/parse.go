@@ -0,0 +1,99 @@
+package temperature
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// unitNames maps the accepted spellings of each unit, in lower case and
+// without a degree sign, to the unit.
+var unitNames = map[string]Unit{
+	"k": UnitKelvin, "kelvin": UnitKelvin, "kelvins": UnitKelvin,
+	"c": UnitCelsius, "celsius": UnitCelsius, "degc": UnitCelsius,
+	"f": UnitFahrenheit, "fahrenheit": UnitFahrenheit, "degf": UnitFahrenheit,
+}
+
+// Parse parses a temperature such as "20°C", "20 C", "68F", "293.15 K" or
+// "-40 °F".
+//
+// The unit is required and may be written as a symbol with or without a degree
+// sign (°C, ºC, ℃, C), or as a word (celsius, fahrenheit, kelvin). Units are
+// case-insensitive.
+func Parse(s string) (Temperature, error) {
+	trimmed := strings.TrimSpace(s)
+	numEnd := strings.IndexFunc(trimmed, func(r rune) bool {
+		return !strings.ContainsRune("+-.0123456789eE", r)
+	})
+	if numEnd < 0 {
+		return 0, fmt.Errorf("missing temperature unit: %q", s)
+	}
+	if numEnd == 0 {
+		return 0, fmt.Errorf("invalid temperature format: %q", s)
+	}
+
+	value, err := strconv.ParseFloat(trimmed[:numEnd], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid temperature value: %q", s)
+	}
+
+	inputUnit := strings.TrimSpace(trimmed[numEnd:])
+	unit, ok := parseUnit(inputUnit)
+	if !ok {
+		return 0, fmt.Errorf("invalid temperature unit: %q", inputUnit)
+	}
+
+	switch unit {
+	case UnitCelsius:
+		return Celsius(value), nil
+	case UnitFahrenheit:
+		return Fahrenheit(value), nil
+	default:
+		return Kelvin(value), nil
+	}
+}
+
+func parseUnit(s string) (Unit, bool) {
+	switch s {
+	case "℃":
+		return UnitCelsius, true
+	case "℉":
+		return UnitFahrenheit, true
+	}
+
+	// An optional degree sign, or the ordinal indicator often typed for it.
+	if r, size := utf8.DecodeRuneInString(s); r == '°' || r == 'º' {
+		s = strings.TrimSpace(s[size:])
+	}
+	u, ok := unitNames[strings.ToLower(s)]
+	return u, ok
+}
+
+// symbol returns the symbol used when formatting the unit.
+func (u Unit) symbol() string {
+	switch u {
+	case UnitKelvin:
+		return "K"
+	case UnitCelsius:
+		return "°C"
+	case UnitFahrenheit:
+		return "°F"
+	default:
+		panic("invalid temperature unit")
+	}
+}
+
+// ascii returns the ASCII symbol of the unit, without a degree sign.
+func (u Unit) ascii() string {
+	switch u {
+	case UnitKelvin:
+		return "K"
+	case UnitCelsius:
+		return "C"
+	case UnitFahrenheit:
+		return "F"
+	default:
+		panic("invalid temperature unit")
+	}
+}
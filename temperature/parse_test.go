@@ -0,0 +1,57 @@
+package temperature
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Temperature
+		wantErr bool
+	}{
+		{"celsius with degree", "20°C", Celsius(20), false},
+		{"celsius with space", "20 C", Celsius(20), false},
+		{"fahrenheit no space", "68F", Fahrenheit(68), false},
+		{"kelvin decimal", "293.15 K", Kelvin(293.15), false},
+		{"negative fahrenheit", "-40 °F", Fahrenheit(-40), false},
+		{"degree with space", "-40 ° F", Fahrenheit(-40), false},
+		{"ordinal indicator", "20ºC", Celsius(20), false},
+		{"celsius sign", "20℃", Celsius(20), false},
+		{"fahrenheit sign", "68℉", Fahrenheit(68), false},
+		{"kelvin sign", "300K", Kelvin(300), false},
+		{"lowercase", "20c", Celsius(20), false},
+		{"word", "20 celsius", Celsius(20), false},
+		{"capitalized word", "300 Kelvin", Kelvin(300), false},
+		{"exponent", "1e2 C", Boiling, false},
+		{"surrounding space", "  0 C  ", Freezing, false},
+		{"String output", "20.00 °C", Celsius(20), false},
+
+		{"empty", "", 0, true},
+		{"missing unit", "20", 0, true},
+		{"missing number", "°C", 0, true},
+		{"unknown unit", "20 X", 0, true},
+		{"bad number", "2-0 C", 0, true},
+		{"double degree", "20 °°C", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(float64(got-tt.want)) > 1e-9 {
+				t.Fatalf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
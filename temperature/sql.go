@@ -0,0 +1,44 @@
+package temperature
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value implements driver.Valuer. The Temperature is stored in kelvin as a
+// float64; use Human to store it as text.
+func (t Temperature) Value() (driver.Value, error) {
+	return float64(t), nil
+}
+
+// Scan implements sql.Scanner. Numeric columns, including numbers stored as
+// text, are read as kelvin; other text goes through Parse.
+func (t *Temperature) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case float64:
+		*t = Kelvin(v)
+		return nil
+	case int64:
+		*t = Kelvin(float64(v))
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case nil:
+		return errors.New("cannot scan NULL into temperature.Temperature")
+	default:
+		return fmt.Errorf("cannot scan %T into temperature.Temperature", src)
+	}
+
+	kelvin, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err == nil {
+		*t = Kelvin(kelvin)
+		return nil
+	}
+	return t.UnmarshalText([]byte(text))
+}
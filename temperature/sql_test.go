@@ -0,0 +1,99 @@
+package temperature
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/Nadim147c/real-go/internal/sqltest"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sqltest.Open()
+	if err != nil {
+		t.Fatalf("open fake database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	db := openDB(t)
+
+	for _, want := range []Temperature{AbsoluteZero, Freezing, Celsius(80)} {
+		var got Temperature
+		if err := db.QueryRow("SELECT ?", want).Scan(&got); err != nil {
+			t.Fatalf("temperature %v: %v", want, err)
+		}
+		if math.Abs(float64(got-want)) > 1e-9 {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		human := Human{Unit: UnitFahrenheit}
+		err := db.QueryRow("SELECT ?", Human{want, UnitFahrenheit}).Scan(&human)
+		if err != nil {
+			t.Fatalf("human temperature %v: %v", want, err)
+		}
+		if math.Abs(float64(human.Temperature-want)) > 1e-9 {
+			t.Fatalf("human: got %v, want %v", human.Temperature, want)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	v, err := Freezing.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != 273.15 {
+		t.Fatalf("got %#v, want %v", v, 273.15)
+	}
+
+	v, err = Human{Freezing, UnitCelsius}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != "0 °C" {
+		t.Fatalf("got %#v, want %q", v, "0 °C")
+	}
+}
+
+func TestScan(t *testing.T) {
+	db := openDB(t)
+
+	tests := []struct {
+		name    string
+		column  any
+		want    Temperature
+		wantErr bool
+	}{
+		{"float64", 273.15, Freezing, false},
+		{"int64", int64(300), Kelvin(300), false},
+		{"numeric bytes", []byte("373.15"), Boiling, false},
+		{"bytes", []byte("100 °C"), Boiling, false},
+		{"string", "32F", Freezing, false},
+		{"null", nil, 0, true},
+		{"bad string", "warm", 0, true},
+		{"bool", true, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Temperature
+			err := db.QueryRow("SELECT ?", tt.column).Scan(&got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(float64(got-tt.want)) > 1e-9 {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ package temperature
 import (
 	"fmt"
 	"math"
+	"strconv"
+
+	"github.com/Nadim147c/real-go/internal/format"
 )
 
 // Temperature is a real-life temperature stored in kelvin.
@@ -68,22 +71,39 @@ func (t Temperature) String() string {
 //   - %C — celsius
 //   - %F — fahrenheit
 //   - %f — alias for %C
+//   - %s and %v — like String, ignoring precision, and %q for the same quoted
+//
+// Width, flags and padding apply to the whole "value unit" text. The + flag
+// forces a sign on non-negative values and the space flag leaves a space for
+// it. The # flag selects the compact ASCII form, such as "20.00C".
 func (t Temperature) Format(f fmt.State, verb rune) {
 	precision, ok := f.Precision()
 	if !ok {
 		precision = 2
 	}
 
-	format := fmt.Sprintf("%%.%df %%s", precision)
-
+	var unit Unit
 	switch verb {
 	case 'K':
-		fmt.Fprintf(f, format, t.In(UnitKelvin), "K")
+		unit = UnitKelvin
 	case 'C', 'f':
-		fmt.Fprintf(f, format, t.In(UnitCelsius), "°C")
+		unit = UnitCelsius
 	case 'F':
-		fmt.Fprintf(f, format, t.In(UnitFahrenheit), "°F")
+		unit = UnitFahrenheit
 	default:
-		fmt.Fprint(f, t.String())
+		if math.IsNaN(float64(t)) {
+			format.Write(f, verb, t.String(), true)
+			return
+		}
+		unit, precision = UnitCelsius, 2
+	}
+
+	value := t.In(unit)
+	text := strconv.FormatFloat(value, 'f', precision, 64)
+	if f.Flag('#') {
+		text += unit.ascii()
+	} else {
+		text += " " + unit.symbol()
 	}
+	format.Write(f, verb, text, !math.Signbit(value))
 }
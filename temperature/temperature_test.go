@@ -92,6 +92,18 @@ func TestFormat(t *testing.T) {
 		{"fahrenheit", "%F", Freezing, "32.00 °F"},
 		{"alias f", "%f", Freezing, "0.00 °C"},
 		{"precision override", "%.1C", Freezing, "0.0 °C"},
+		{"width", "%10.1C", Freezing, "    0.0 °C"},
+		{"left", "%-10.1C|", Freezing, "0.0 °C    |"},
+		{"plus", "%+.1C", Boiling, "+100.0 °C"},
+		{"plus negative", "%+.0F", Celsius(-40), "-40 °F"},
+		{"space", "% .1C", Freezing, " 0.0 °C"},
+		{"ascii", "%#.1F", Boiling, "212.0F"},
+		{"ascii kelvin", "%#K", Freezing, "273.15K"},
+		{"value", "%v", Celsius(20), "20.00 °C"},
+		{"value width", "%12v", Celsius(20), "    20.00 °C"},
+		{"string ignores precision", "%.1s", Celsius(20), "20.00 °C"},
+		{"quoted", "%q", Celsius(20), `"20.00 °C"`},
+		{"quoted ascii", "%#q", Celsius(20), `"20.00C"`},
 	}
 
 	for _, tt := range tests {
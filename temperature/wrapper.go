@@ -0,0 +1,96 @@
+package temperature
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Raw is a Temperature that is encoded as its number of kelvin everywhere: in
+// text and JSON, in a database and in logs. Decoding accepts both a number in
+// kelvin and anything Parse understands.
+type Raw Temperature
+
+// String returns the same text as Temperature.String.
+func (r Raw) String() string {
+	return Temperature(r).String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Raw) MarshalText() ([]byte, error) {
+	return strconv.AppendFloat(nil, float64(r), 'f', -1, 64), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Raw) UnmarshalText(text []byte) error {
+	str := strings.TrimSpace(string(text))
+	if kelvin, err := strconv.ParseFloat(str, 64); err == nil {
+		*r = Raw(kelvin)
+		return nil
+	}
+	return (*Temperature)(r).UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler. The temperature is written as a JSON
+// number in kelvin.
+func (r Raw) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Temperature.UnmarshalJSON.
+func (r *Raw) UnmarshalJSON(data []byte) error {
+	return (*Temperature)(r).UnmarshalJSON(data)
+}
+
+// Value implements driver.Valuer like Temperature.Value.
+func (r Raw) Value() (driver.Value, error) {
+	return float64(r), nil
+}
+
+// Scan implements sql.Scanner like Temperature.Scan.
+func (r *Raw) Scan(src any) error {
+	return (*Temperature)(r).Scan(src)
+}
+
+// Human is a Temperature that is encoded as text in Unit, such as "68 °F", in
+// text and JSON, in a database and in logs. Use it for the fields that should
+// not be written in celsius, or not stored as a number. The zero Unit is
+// kelvin. Decoding accepts everything Temperature does and leaves Unit
+// unchanged.
+type Human struct {
+	Temperature
+	Unit Unit
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (h Human) MarshalText() ([]byte, error) {
+	return marshalIn(h.Temperature, h.Unit), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using Parse.
+func (h *Human) UnmarshalText(text []byte) error {
+	return h.Temperature.UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler. The temperature is written as a JSON
+// string like MarshalText.
+func (h Human) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(marshalIn(h.Temperature, h.Unit)))
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Temperature.UnmarshalJSON.
+func (h *Human) UnmarshalJSON(data []byte) error {
+	return h.Temperature.UnmarshalJSON(data)
+}
+
+// Value implements driver.Valuer. The temperature is stored as text like
+// MarshalText.
+func (h Human) Value() (driver.Value, error) {
+	return string(marshalIn(h.Temperature, h.Unit)), nil
+}
+
+// Scan implements sql.Scanner like Temperature.Scan.
+func (h *Human) Scan(src any) error {
+	return h.Temperature.Scan(src)
+}